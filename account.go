@@ -30,7 +30,7 @@ type Account struct {
 	CurrencyRef                   *ReferenceType       `json:",omitempty"`
 	ParentRef                     *ReferenceType       `json:",omitempty"`
 	TaxCodeRef                    *ReferenceType       `json:",omitempty"`
-	MetaData                      ModificationMetaData `json:",omitempty"`
+	MetaData                      ModificationMetaData `json:",omitzero"`
 	CurrentBalanceWithSubAccounts json.Number          `json:",omitempty"`
 	CurrentBalance                json.Number          `json:",omitempty"`
 	AccountType                   AccountTypeEnum      `json:",omitempty"`
@@ -49,6 +49,21 @@ type Account struct {
 	Status                        string `json:"status,omitempty"`
 	// AccountAlias                  string               `json:",omitempty"`
 	// TxnLocationType
+
+	// Unknown holds the fields QuickBooks sent that Account does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes an Account, keeping the fields it does not model in Unknown.
+func (a *Account) UnmarshalJSON(data []byte) error {
+	type account Account
+	return unmarshalWithUnknown(data, (*account)(a), &a.Unknown)
+}
+
+// MarshalJSON encodes an Account along with its Unknown fields.
+func (a Account) MarshalJSON() ([]byte, error) {
+	type account Account
+	return marshalWithUnknown(account(a), a.Unknown)
 }
 
 // CreateAccount creates the given account within QuickBooks
@@ -67,36 +82,7 @@ func (c *Client) CreateAccount(params RequestParameters, account *Account) (*Acc
 
 // FindAccounts gets the full list of Accounts in the QuickBooks account.
 func (c *Client) FindAccounts(params RequestParameters) ([]Account, error) {
-	var resp struct {
-		QueryResponse struct {
-			Accounts      []Account `json:"Account"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Account", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	accounts := make([]Account, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Account ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		accounts = append(accounts, resp.QueryResponse.Accounts...)
-	}
-
-	return accounts, nil
+	return queryAll[Account](c, params, "")
 }
 
 func (c *Client) FindAccountsByPage(params RequestParameters, startPosition, pageSize int) ([]Account, error) {
@@ -133,7 +119,7 @@ func (c *Client) FindAccountById(params RequestParameters, id string) (*Account,
 }
 
 // QueryAccounts accepts an SQL query and returns all accounts found using it
-func (c *Client) QueryAccounts(params RequestParameters, query string) ([]Account, error) {
+func (c *Client) QueryAccounts(params RequestParameters, query QueryStatement) ([]Account, error) {
 	var resp struct {
 		QueryResponse struct {
 			Accounts      []Account `json:"Account"`
@@ -142,7 +128,7 @@ func (c *Client) QueryAccounts(params RequestParameters, query string) ([]Accoun
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -155,12 +141,14 @@ func (c *Client) UpdateAccount(params RequestParameters, account *Account) (*Acc
 		return nil, errors.New("missing account id")
 	}
 
-	existingAccount, err := c.FindAccountById(params, account.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingAccount, err := c.FindAccountById(params, account.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	account.SyncToken = existingAccount.SyncToken
+		account.SyncToken = existingAccount.SyncToken
+	}
 
 	payload := struct {
 		*Account
@@ -173,9 +161,9 @@ func (c *Client) UpdateAccount(params RequestParameters, account *Account) (*Acc
 		Time    Date
 	}
 
-	if err = c.post(params, "account", payload, &accountData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "account", payload, &accountData, nil); err != nil {
+		return nil, conflictError(params, account.Id, account, err, c.FindAccountById)
 	}
 
-	return &accountData.Account, err
+	return &accountData.Account, nil
 }
@@ -0,0 +1,624 @@
+package quickbooks
+
+// The accessors below read amount, quantity and rate fields as Decimals.
+// The fields stay json.Number so that existing code keeps compiling; assign
+// a Decimal to one with Decimal.Number.
+
+// CurrentBalanceWithSubAccountsDecimal returns CurrentBalanceWithSubAccounts as a Decimal.
+func (a *Account) CurrentBalanceWithSubAccountsDecimal() (Decimal, error) {
+	return DecimalFromNumber(a.CurrentBalanceWithSubAccounts)
+}
+
+// CurrentBalanceDecimal returns CurrentBalance as a Decimal.
+func (a *Account) CurrentBalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(a.CurrentBalance)
+}
+
+// SizeDecimal returns Size as a Decimal.
+func (a *Attachable) SizeDecimal() (Decimal, error) {
+	return DecimalFromNumber(a.Size)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (b *Bill) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(b.TotalAmt)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (b *Bill) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(b.ExchangeRate)
+}
+
+// HomeBalanceDecimal returns HomeBalance as a Decimal.
+func (b *Bill) HomeBalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(b.HomeBalance)
+}
+
+// BalanceDecimal returns Balance as a Decimal.
+func (b *Bill) BalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(b.Balance)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (b *BillPayment) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(b.TotalAmt)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (b *BillPayment) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(b.ExchangeRate)
+}
+
+// RemainingCreditDecimal returns RemainingCredit as a Decimal.
+func (c *CreditMemo) RemainingCreditDecimal() (Decimal, error) {
+	return DecimalFromNumber(c.RemainingCredit)
+}
+
+// BalanceDecimal returns Balance as a Decimal.
+func (c *CreditMemo) BalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(c.Balance)
+}
+
+// BalanceDecimal returns Balance as a Decimal.
+func (c *Customer) BalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(c.Balance)
+}
+
+// BalanceWithJobsDecimal returns BalanceWithJobs as a Decimal.
+func (c *Customer) BalanceWithJobsDecimal() (Decimal, error) {
+	return DecimalFromNumber(c.BalanceWithJobs)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (d *Deposit) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(d.ExchangeRate)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (d *Deposit) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(d.TotalAmt)
+}
+
+// HomeTotalAmtDecimal returns HomeTotalAmt as a Decimal.
+func (d *Deposit) HomeTotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(d.HomeTotalAmt)
+}
+
+// CostRateDecimal returns CostRate as a Decimal.
+func (e *Employee) CostRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(e.CostRate)
+}
+
+// BillRateDecimal returns BillRate as a Decimal.
+func (e *Employee) BillRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(e.BillRate)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (e *Estimate) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(e.ExchangeRate)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (e *Estimate) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(e.TotalAmt)
+}
+
+// HomeTotalAmtDecimal returns HomeTotalAmt as a Decimal.
+func (e *Estimate) HomeTotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(e.HomeTotalAmt)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (i *Invoice) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.ExchangeRate)
+}
+
+// DepositDecimal returns Deposit as a Decimal.
+func (i *Invoice) DepositDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.Deposit)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (i *Invoice) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.TotalAmt)
+}
+
+// BalanceDecimal returns Balance as a Decimal.
+func (i *Invoice) BalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.Balance)
+}
+
+// HomeAmtTotalDecimal returns HomeAmtTotal as a Decimal.
+func (i *Invoice) HomeAmtTotalDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.HomeAmtTotal)
+}
+
+// HomeBalanceDecimal returns HomeBalance as a Decimal.
+func (i *Invoice) HomeBalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.HomeBalance)
+}
+
+// QtyOnHandDecimal returns QtyOnHand as a Decimal.
+func (i *Item) QtyOnHandDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.QtyOnHand)
+}
+
+// ReorderPointDecimal returns ReorderPoint as a Decimal.
+func (i *Item) ReorderPointDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.ReorderPoint)
+}
+
+// PurchaseCostDecimal returns PurchaseCost as a Decimal.
+func (i *Item) PurchaseCostDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.PurchaseCost)
+}
+
+// UnitPriceDecimal returns UnitPrice as a Decimal.
+func (i *Item) UnitPriceDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.UnitPrice)
+}
+
+// LevelDecimal returns Level as a Decimal.
+func (i *Item) LevelDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.Level)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (j *JournalEntry) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(j.ExchangeRate)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (j *JournalEntry) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(j.TotalAmt)
+}
+
+// HomeTotalAmtDecimal returns HomeTotalAmt as a Decimal.
+func (j *JournalEntry) HomeTotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(j.HomeTotalAmt)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (p *Payment) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(p.ExchangeRate)
+}
+
+// UnappliedAmtDecimal returns UnappliedAmt as a Decimal.
+func (p *Payment) UnappliedAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(p.UnappliedAmt)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (p *Payment) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(p.TotalAmt)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (p *PurchaseOrder) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(p.ExchangeRate)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (p *PurchaseOrder) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(p.TotalAmt)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (p *Purchase) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(p.ExchangeRate)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (p *Purchase) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(p.TotalAmt)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (r *RefundReceipt) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(r.ExchangeRate)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (r *RefundReceipt) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(r.TotalAmt)
+}
+
+// HomeTotalAmtDecimal returns HomeTotalAmt as a Decimal.
+func (r *RefundReceipt) HomeTotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(r.HomeTotalAmt)
+}
+
+// BalanceDecimal returns Balance as a Decimal.
+func (r *RefundReceipt) BalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(r.Balance)
+}
+
+// AmountDecimal returns Amount as a Decimal.
+func (r *ReimburseCharge) AmountDecimal() (Decimal, error) {
+	return DecimalFromNumber(r.Amount)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (r *ReimburseCharge) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(r.ExchangeRate)
+}
+
+// HomeTotalAmtDecimal returns HomeTotalAmt as a Decimal.
+func (r *ReimburseCharge) HomeTotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(r.HomeTotalAmt)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (s *SalesReceipt) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(s.ExchangeRate)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (s *SalesReceipt) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(s.TotalAmt)
+}
+
+// HomeTotalAmtDecimal returns HomeTotalAmt as a Decimal.
+func (s *SalesReceipt) HomeTotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(s.HomeTotalAmt)
+}
+
+// BalanceDecimal returns Balance as a Decimal.
+func (s *SalesReceipt) BalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(s.Balance)
+}
+
+// RateValueDecimal returns RateValue as a Decimal.
+func (t *TaxRate) RateValueDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.RateValue)
+}
+
+// DiscountPercentDecimal returns DiscountPercent as a Decimal.
+func (t *Term) DiscountPercentDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.DiscountPercent)
+}
+
+// DiscountDaysDecimal returns DiscountDays as a Decimal.
+func (t *Term) DiscountDaysDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.DiscountDays)
+}
+
+// DayOfMonthDueDecimal returns DayOfMonthDue as a Decimal.
+func (t *Term) DayOfMonthDueDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.DayOfMonthDue)
+}
+
+// DiscountDayOfMonthDecimal returns DiscountDayOfMonth as a Decimal.
+func (t *Term) DiscountDayOfMonthDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.DiscountDayOfMonth)
+}
+
+// DueNextMonthDaysDecimal returns DueNextMonthDays as a Decimal.
+func (t *Term) DueNextMonthDaysDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.DueNextMonthDays)
+}
+
+// DueDaysDecimal returns DueDays as a Decimal.
+func (t *Term) DueDaysDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.DueDays)
+}
+
+// BreakHoursDecimal returns BreakHours as a Decimal.
+func (t *TimeActivity) BreakHoursDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.BreakHours)
+}
+
+// BreakMinutesDecimal returns BreakMinutes as a Decimal.
+func (t *TimeActivity) BreakMinutesDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.BreakMinutes)
+}
+
+// BreakSecondsDecimal returns BreakSeconds as a Decimal.
+func (t *TimeActivity) BreakSecondsDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.BreakSeconds)
+}
+
+// HoursDecimal returns Hours as a Decimal.
+func (t *TimeActivity) HoursDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.Hours)
+}
+
+// MinutesDecimal returns Minutes as a Decimal.
+func (t *TimeActivity) MinutesDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.Minutes)
+}
+
+// SecondsDecimal returns Seconds as a Decimal.
+func (t *TimeActivity) SecondsDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.Seconds)
+}
+
+// HourlyRateDecimal returns HourlyRate as a Decimal.
+func (t *TimeActivity) HourlyRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.HourlyRate)
+}
+
+// CostRateDecimal returns CostRate as a Decimal.
+func (t *TimeActivity) CostRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.CostRate)
+}
+
+// AmountDecimal returns Amount as a Decimal.
+func (t *Transfer) AmountDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.Amount)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (t *Transfer) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.ExchangeRate)
+}
+
+// CostRateDecimal returns CostRate as a Decimal.
+func (v *Vendor) CostRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(v.CostRate)
+}
+
+// BillRateDecimal returns BillRate as a Decimal.
+func (v *Vendor) BillRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(v.BillRate)
+}
+
+// BalanceDecimal returns Balance as a Decimal.
+func (v *Vendor) BalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(v.Balance)
+}
+
+// TotalAmtDecimal returns TotalAmt as a Decimal.
+func (v *VendorCredit) TotalAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(v.TotalAmt)
+}
+
+// BalanceDecimal returns Balance as a Decimal.
+func (v *VendorCredit) BalanceDecimal() (Decimal, error) {
+	return DecimalFromNumber(v.Balance)
+}
+
+// ExchangeRateDecimal returns ExchangeRate as a Decimal.
+func (v *VendorCredit) ExchangeRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(v.ExchangeRate)
+}
+
+// PercentDecimal returns Percent as a Decimal.
+func (m *MarkupInfo) PercentDecimal() (Decimal, error) {
+	return DecimalFromNumber(m.Percent)
+}
+
+// TotalTaxDecimal returns TotalTax as a Decimal.
+func (t *TxnTaxDetail) TotalTaxDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.TotalTax)
+}
+
+// AmountDecimal returns Amount as a Decimal.
+func (l *Line) AmountDecimal() (Decimal, error) {
+	return DecimalFromNumber(l.Amount)
+}
+
+// TaxAmountDecimal returns TaxAmount as a Decimal.
+func (a *AccountBasedExpenseLineDetail) TaxAmountDecimal() (Decimal, error) {
+	return DecimalFromNumber(a.TaxAmount)
+}
+
+// QtyDecimal returns Qty as a Decimal.
+func (i *ItemBasedExpenseLineDetail) QtyDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.Qty)
+}
+
+// UnitPriceDecimal returns UnitPrice as a Decimal.
+func (i *ItemBasedExpenseLineDetail) UnitPriceDecimal() (Decimal, error) {
+	return DecimalFromNumber(i.UnitPrice)
+}
+
+// UnitPriceDecimal returns UnitPrice as a Decimal.
+func (s *SalesItemLineDetail) UnitPriceDecimal() (Decimal, error) {
+	return DecimalFromNumber(s.UnitPrice)
+}
+
+// QtyDecimal returns Qty as a Decimal.
+func (s *SalesItemLineDetail) QtyDecimal() (Decimal, error) {
+	return DecimalFromNumber(s.Qty)
+}
+
+// TaxInclusiveAmtDecimal returns TaxInclusiveAmt as a Decimal.
+func (s *SalesItemLineDetail) TaxInclusiveAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(s.TaxInclusiveAmt)
+}
+
+// DiscountRateDecimal returns DiscountRate as a Decimal.
+func (s *SalesItemLineDetail) DiscountRateDecimal() (Decimal, error) {
+	return DecimalFromNumber(s.DiscountRate)
+}
+
+// DiscountAmtDecimal returns DiscountAmt as a Decimal.
+func (s *SalesItemLineDetail) DiscountAmtDecimal() (Decimal, error) {
+	return DecimalFromNumber(s.DiscountAmt)
+}
+
+// QuantityDecimal returns Quantity as a Decimal.
+func (g *GroupLineDetail) QuantityDecimal() (Decimal, error) {
+	return DecimalFromNumber(g.Quantity)
+}
+
+// DiscountPercentDecimal returns DiscountPercent as a Decimal.
+func (d *DiscountLineDetail) DiscountPercentDecimal() (Decimal, error) {
+	return DecimalFromNumber(d.DiscountPercent)
+}
+
+// NetAmountTaxableDecimal returns NetAmountTaxable as a Decimal.
+func (t *TaxLineDetail) NetAmountTaxableDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.NetAmountTaxable)
+}
+
+// TaxInclusiveAmountDecimal returns TaxInclusiveAmount as a Decimal.
+func (t *TaxLineDetail) TaxInclusiveAmountDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.TaxInclusiveAmount)
+}
+
+// OverrideDeltaAmountDecimal returns OverrideDeltaAmount as a Decimal.
+func (t *TaxLineDetail) OverrideDeltaAmountDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.OverrideDeltaAmount)
+}
+
+// TaxPercentDecimal returns TaxPercent as a Decimal.
+func (t *TaxLineDetail) TaxPercentDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.TaxPercent)
+}
+
+// DiscountPercentDecimal returns DiscountPercent as a Decimal.
+func (r *ReimburseLineDetail) DiscountPercentDecimal() (Decimal, error) {
+	return DecimalFromNumber(r.DiscountPercent)
+}
+
+// QtyDecimal returns Qty as a Decimal.
+func (r *ReimburseLineDetail) QtyDecimal() (Decimal, error) {
+	return DecimalFromNumber(r.Qty)
+}
+
+// UnitPriceDecimal returns UnitPrice as a Decimal.
+func (r *ReimburseLineDetail) UnitPriceDecimal() (Decimal, error) {
+	return DecimalFromNumber(r.UnitPrice)
+}
+
+// TaxAmountDecimal returns TaxAmount as a Decimal.
+func (j *JournalEntryLineDetail) TaxAmountDecimal() (Decimal, error) {
+	return DecimalFromNumber(j.TaxAmount)
+}
+
+// TaxOrderDecimal returns TaxOrder as a Decimal.
+func (t *TaxRateDetail) TaxOrderDecimal() (Decimal, error) {
+	return DecimalFromNumber(t.TaxOrder)
+}
+
+// The Money accessors below pair amounts in an entity's own currency with
+// its CurrencyRef. The currency is empty when QuickBooks leaves CurrencyRef
+// out, as it does for companies without multicurrency.
+
+// CurrentBalanceMoney returns CurrentBalance in the Account's currency.
+func (a *Account) CurrentBalanceMoney() (Money, error) {
+	return moneyFromNumber(a.CurrentBalance, a.CurrencyRef)
+}
+
+// CurrentBalanceWithSubAccountsMoney returns CurrentBalanceWithSubAccounts in the Account's currency.
+func (a *Account) CurrentBalanceWithSubAccountsMoney() (Money, error) {
+	return moneyFromNumber(a.CurrentBalanceWithSubAccounts, a.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the Bill's currency.
+func (b *Bill) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(b.TotalAmt, &b.CurrencyRef)
+}
+
+// BalanceMoney returns Balance in the Bill's currency.
+func (b *Bill) BalanceMoney() (Money, error) {
+	return moneyFromNumber(b.Balance, &b.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the BillPayment's currency.
+func (b *BillPayment) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(b.TotalAmt, &b.CurrencyRef)
+}
+
+// BalanceMoney returns Balance in the Customer's currency.
+func (c *Customer) BalanceMoney() (Money, error) {
+	return moneyFromNumber(c.Balance, c.CurrencyRef)
+}
+
+// BalanceWithJobsMoney returns BalanceWithJobs in the Customer's currency.
+func (c *Customer) BalanceWithJobsMoney() (Money, error) {
+	return moneyFromNumber(c.BalanceWithJobs, c.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the Deposit's currency.
+func (d *Deposit) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(d.TotalAmt, &d.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the Estimate's currency.
+func (e *Estimate) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(e.TotalAmt, &e.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the Invoice's currency.
+func (i *Invoice) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(i.TotalAmt, &i.CurrencyRef)
+}
+
+// BalanceMoney returns Balance in the Invoice's currency.
+func (i *Invoice) BalanceMoney() (Money, error) {
+	return moneyFromNumber(i.Balance, &i.CurrencyRef)
+}
+
+// DepositMoney returns Deposit in the Invoice's currency.
+func (i *Invoice) DepositMoney() (Money, error) {
+	return moneyFromNumber(i.Deposit, &i.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the JournalEntry's currency.
+func (j *JournalEntry) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(j.TotalAmt, &j.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the Payment's currency.
+func (p *Payment) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(p.TotalAmt, &p.CurrencyRef)
+}
+
+// UnappliedAmtMoney returns UnappliedAmt in the Payment's currency.
+func (p *Payment) UnappliedAmtMoney() (Money, error) {
+	return moneyFromNumber(p.UnappliedAmt, &p.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the Purchase's currency.
+func (p *Purchase) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(p.TotalAmt, &p.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the PurchaseOrder's currency.
+func (p *PurchaseOrder) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(p.TotalAmt, &p.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the RefundReceipt's currency.
+func (r *RefundReceipt) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(r.TotalAmt, &r.CurrencyRef)
+}
+
+// BalanceMoney returns Balance in the RefundReceipt's currency.
+func (r *RefundReceipt) BalanceMoney() (Money, error) {
+	return moneyFromNumber(r.Balance, &r.CurrencyRef)
+}
+
+// AmountMoney returns Amount in the ReimburseCharge's currency.
+func (r *ReimburseCharge) AmountMoney() (Money, error) {
+	return moneyFromNumber(r.Amount, &r.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the SalesReceipt's currency.
+func (s *SalesReceipt) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(s.TotalAmt, &s.CurrencyRef)
+}
+
+// BalanceMoney returns Balance in the SalesReceipt's currency.
+func (s *SalesReceipt) BalanceMoney() (Money, error) {
+	return moneyFromNumber(s.Balance, &s.CurrencyRef)
+}
+
+// BalanceMoney returns Balance in the Vendor's currency.
+func (v *Vendor) BalanceMoney() (Money, error) {
+	return moneyFromNumber(v.Balance, v.CurrencyRef)
+}
+
+// TotalAmtMoney returns TotalAmt in the VendorCredit's currency.
+func (v *VendorCredit) TotalAmtMoney() (Money, error) {
+	return moneyFromNumber(v.TotalAmt, &v.CurrencyRef)
+}
+
+// BalanceMoney returns Balance in the VendorCredit's currency.
+func (v *VendorCredit) BalanceMoney() (Money, error) {
+	return moneyFromNumber(v.Balance, &v.CurrencyRef)
+}
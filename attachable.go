@@ -2,6 +2,7 @@ package quickbooks
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,7 +11,6 @@ import (
 	"net/http"
 	"net/textproto"
 	"net/url"
-	"strconv"
 	"strings"
 )
 
@@ -48,12 +48,27 @@ type Attachable struct {
 	Long                     string               `json:",omitempty"`
 	Tag                      string               `json:",omitempty"`
 	Lat                      string               `json:",omitempty"`
-	MetaData                 ModificationMetaData `json:",omitempty"`
+	MetaData                 ModificationMetaData `json:",omitzero"`
 	FileAccessUri            string               `json:",omitempty"`
 	Size                     json.Number          `json:",omitempty"`
 	ThumbnailFileAccessUri   string               `json:",omitempty"`
 	TempDownloadUri          string               `json:",omitempty"`
 	ThumbnailTempDownloadUri string               `json:",omitempty"`
+
+	// Unknown holds the fields QuickBooks sent that Attachable does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes an Attachable, keeping the fields it does not model in Unknown.
+func (a *Attachable) UnmarshalJSON(data []byte) error {
+	type attachable Attachable
+	return unmarshalWithUnknown(data, (*attachable)(a), &a.Unknown)
+}
+
+// MarshalJSON encodes an Attachable along with its Unknown fields.
+func (a Attachable) MarshalJSON() ([]byte, error) {
+	type attachable Attachable
+	return marshalWithUnknown(attachable(a), a.Unknown)
 }
 
 type AttachableRef struct {
@@ -91,65 +106,6 @@ func (c *Client) DeleteAttachable(params RequestParameters, attachable *Attachab
 
 // DownloadAttachable downloads the attachable
 func (c *Client) GetAttachableDownloadURL(params RequestParameters, id string) (*url.URL, error) {
-	// 1. global concurrency semaphore
-	if params.WaitOnRateLimit {
-		select {
-		case c.globalConcurrent <- struct{}{}:
-		case <-params.Ctx.Done():
-			return nil, params.Ctx.Err()
-		}
-		defer func() { <-c.globalConcurrent }()
-	} else {
-		select {
-		case c.globalConcurrent <- struct{}{}:
-			defer func() { <-c.globalConcurrent }()
-		default:
-			return nil, NewRateLimitError(globalConcurrentRL)
-		}
-	}
-
-	// 2. global rate limiter
-	if params.WaitOnRateLimit {
-		if err := c.globalRateLimiter.Wait(params.Ctx); err != nil {
-			return nil, fmt.Errorf("global rate limiter wait error: %v", err)
-		}
-	} else {
-		if !c.globalRateLimiter.Allow() {
-			return nil, NewRateLimitError(globalGeneralRL)
-		}
-	}
-
-	// 3. retrieve the per-realm limiter.
-	limiter := c.rateLimiter.getRealmLimiter(params.RealmId)
-
-	// 4. realm-general rate limiter
-	if params.WaitOnRateLimit {
-		if err := limiter.general.Wait(params.Ctx); err != nil {
-			return nil, fmt.Errorf("realm rate limiter wait error: %v", err)
-		}
-	} else {
-		if !limiter.general.Allow() {
-			return nil, NewRateLimitError(realmGeneralRL)
-		}
-	}
-
-	// 5. realm-concurrency semaphore
-	if params.WaitOnRateLimit {
-		select {
-		case limiter.concurrent <- struct{}{}:
-		case <-params.Ctx.Done():
-			return nil, params.Ctx.Err()
-		}
-		defer func() { <-limiter.concurrent }()
-	} else {
-		select {
-		case limiter.concurrent <- struct{}{}:
-			defer func() { <-limiter.concurrent }()
-		default:
-			return nil, NewRateLimitError(realmConcurrentRL)
-		}
-	}
-
 	// Build the full endpoint URL including realmId.
 	endpointUrl := *c.baseEndpoint
 	endpointUrl.Path += params.RealmId + "/download/" + id
@@ -159,22 +115,33 @@ func (c *Client) GetAttachableDownloadURL(params RequestParameters, id string) (
 	urlValues.Set("minorversion", c.minorVersion)
 	endpointUrl.RawQuery = urlValues.Encode()
 
-	req, err := http.NewRequestWithContext(params.Ctx, http.MethodGet, endpointUrl.String(), nil)
+	accessToken, err := c.accessToken(params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, err
 	}
 
-	req.Header.Add("Accept", "*/*")
-	req.Header.Add("Authorization", "Bearer "+params.Token.AccessToken)
+	resp, err := c.do(params, true, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(params.Ctx, http.MethodGet, endpointUrl.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Add("Accept", "*/*")
+		req.Header.Add("Authorization", "Bearer "+accessToken)
 
-	resp, err := c.Client.Do(req)
+		return req, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %v", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status %d from QuickBooks", resp.StatusCode)
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusTooManyRequests:
+		return nil, NewRateLimitError(apiRl)
+	default:
+		return nil, parseFailure(resp)
 	}
 
 	b, err := io.ReadAll(resp.Body)
@@ -192,39 +159,15 @@ func (c *Client) GetAttachableDownloadURL(params RequestParameters, id string) (
 
 // FindAttachables gets the full list of Attachables in the QuickBooks attachable.
 func (c *Client) FindAttachables(params RequestParameters) ([]Attachable, error) {
-	var resp struct {
-		QueryResponse struct {
-			Attachables   []Attachable `json:"Attachable"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Attachable", &resp); err != nil {
+	attachables, err := queryAll[Attachable](c, params, "")
+	if err != nil {
 		return nil, err
 	}
 
-	if resp.QueryResponse.TotalCount == 0 {
+	if len(attachables) == 0 {
 		return nil, errors.New("no attachables could be found")
 	}
 
-	attachables := make([]Attachable, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Attachable ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		if resp.QueryResponse.Attachables == nil {
-			return nil, errors.New("no attachables could be found")
-		}
-
-		attachables = append(attachables, resp.QueryResponse.Attachables...)
-	}
-
 	return attachables, nil
 }
 
@@ -243,7 +186,7 @@ func (c *Client) FindAttachableById(params RequestParameters, id string) (*Attac
 }
 
 // QueryAttachables accepts an SQL query and returns all attachables found using it
-func (c *Client) QueryAttachables(params RequestParameters, query string) ([]Attachable, error) {
+func (c *Client) QueryAttachables(params RequestParameters, query QueryStatement) ([]Attachable, error) {
 	var resp struct {
 		QueryResponse struct {
 			Attachables   []Attachable `json:"Attachable"`
@@ -252,7 +195,7 @@ func (c *Client) QueryAttachables(params RequestParameters, query string) ([]Att
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -269,40 +212,75 @@ func (c *Client) UpdateAttachable(params RequestParameters, attachable *Attachab
 		return nil, errors.New("missing attachable id")
 	}
 
-	existingAttachable, err := c.FindAttachableById(params, attachable.Id)
-	if err != nil {
-		return nil, err
-	}
-
-	attachable.SyncToken = existingAttachable.SyncToken
+	if !params.StrictConcurrency {
+		existingAttachable, err := c.FindAttachableById(params, attachable.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	payload := struct {
-		*Attachable
-		Sparse bool `json:"sparse"`
-	}{
-		Attachable: attachable,
-		Sparse:     true,
+		attachable.SyncToken = existingAttachable.SyncToken
 	}
 
+	payload := sparsePayload{attachable}
+
 	var attachableData struct {
 		Attachable Attachable
 		Time       Date
 	}
 
-	if err = c.post(params, "attachable", payload, &attachableData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "attachable", payload, &attachableData, nil); err != nil {
+		return nil, conflictError(params, attachable.Id, attachable, err, c.FindAttachableById)
 	}
 
-	return &attachableData.Attachable, err
+	return &attachableData.Attachable, nil
 }
 
 // UploadAttachable uploads the attachable
+//
+// Deprecated: use UploadAttachableWithParams, which takes a context, a
+// token and a request id like the other requests.
+//
+// The token stored for the realm authorizes the upload. Without one, the
+// request is sent with no Authorization header, leaving it to an
+// authenticating http.Client as before.
 func (c *Client) UploadAttachable(realmId string, attachable *Attachable, data io.Reader) (*Attachable, error) {
+	params := RequestParameters{Ctx: context.Background(), WaitOnRateLimit: true, RealmId: realmId}
+
+	var accessToken string
+	token, err := c.tokens.Token(params.Ctx, realmId)
+	switch {
+	case err == nil:
+		accessToken = token.AccessToken
+	case !errors.Is(err, ErrTokenNotFound):
+		return nil, err
+	}
+
+	return c.uploadAttachable(params, accessToken, attachable, data)
+}
+
+// UploadAttachableWithParams uploads the attachable
+//
+// The upload is only retried when params.RequestId is set.
+func (c *Client) UploadAttachableWithParams(params RequestParameters, attachable *Attachable, data io.Reader) (*Attachable, error) {
+	accessToken, err := c.accessToken(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.uploadAttachable(params, accessToken, attachable, data)
+}
+
+// uploadAttachable sends the upload, authorized by accessToken unless it is
+// empty.
+func (c *Client) uploadAttachable(params RequestParameters, accessToken string, attachable *Attachable, data io.Reader) (*Attachable, error) {
 	endpointUrl := *c.baseEndpoint
-	endpointUrl.Path += realmId + "/upload"
+	endpointUrl.Path += params.RealmId + "/upload"
 
 	urlValues := url.Values{}
 	urlValues.Add("minorversion", c.minorVersion)
+	if params.RequestId != "" {
+		urlValues.Set("requestid", params.RequestId)
+	}
 	endpointUrl.RawQuery = urlValues.Encode()
 
 	var buffer bytes.Buffer
@@ -343,22 +321,33 @@ func (c *Client) UploadAttachable(realmId string, attachable *Attachable, data i
 
 	mWriter.Close()
 
-	req, err := http.NewRequest("POST", endpointUrl.String(), &buffer)
-	if err != nil {
-		return nil, err
-	}
+	body := buffer.Bytes()
 
-	req.Header.Add("Content-Type", mWriter.FormDataContentType())
-	req.Header.Add("Accept", "application/json")
+	resp, err := c.do(params, params.RequestId != "", func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(params.Ctx, "POST", endpointUrl.String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Add("Content-Type", mWriter.FormDataContentType())
+		req.Header.Add("Accept", "application/json")
+		if accessToken != "" {
+			req.Header.Add("Authorization", "Bearer "+accessToken)
+		}
 
-	resp, err := c.Client.Do(req)
+		return req, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusTooManyRequests:
+		return nil, NewRateLimitError(apiRl)
+	default:
 		return nil, parseFailure(resp)
 	}
 
@@ -1,10 +1,14 @@
 package quickbooks
 
 import (
+	"context"
 	"encoding/json"
 	"io"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,3 +37,23 @@ func TestAttachable(t *testing.T) {
 	assert.Equal(t, "2015-11-17T11:05:15-08:00", r.Attachable.MetaData.CreateTime.String())
 	assert.Equal(t, "2015-11-17T11:05:15-08:00", r.Attachable.MetaData.LastUpdatedTime.String())
 }
+
+func TestUploadAttachableLegacy(t *testing.T) {
+	var authorization string
+	client, _ := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Write([]byte(`{"AttachableResponse":[{"Attachable":{"Id":"5","FileName":"receipt.txt"}}]}`))
+	})
+
+	// Without a stored token the request is left to the http.Client to
+	// authorize, as it was before tokens were managed.
+	attachable, err := client.UploadAttachable("1234", &Attachable{FileName: "receipt.txt", ContentType: TXT}, strings.NewReader("paid"))
+	require.NoError(t, err)
+	assert.Equal(t, "5", attachable.Id)
+	assert.Empty(t, authorization)
+
+	require.NoError(t, client.tokens.SetToken(context.Background(), "1234", &BearerToken{AccessToken: "stored", Expiry: time.Now().Add(time.Hour)}))
+	_, err = client.UploadAttachable("1234", &Attachable{FileName: "receipt.txt", ContentType: TXT}, strings.NewReader("paid"))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer stored", authorization)
+}
@@ -1,9 +1,14 @@
 package quickbooks
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,13 +26,16 @@ const Void BatchOptions = "void"
 
 type BatchFault struct {
 	Message string
-	Code    string `json:"code"`
+	Code    FaultCode `json:"code"`
 	Detail  string
 	Element string `json:"element"`
 }
 
+// BatchError holds the faults QuickBooks returned for a single batch item.
 type BatchError struct {
-	Faults []BatchFault
+	BID       string
+	FaultType string
+	Faults    []BatchFault
 }
 
 func (e BatchError) Error() string {
@@ -36,14 +44,217 @@ func (e BatchError) Error() string {
 		// include code, element, and message
 		msgs[i] = fmt.Sprintf("%s/%s: %s", f.Code, f.Element, f.Message)
 	}
-	return "batch faults: " + strings.Join(msgs, "; ")
+	text := "batch faults: " + strings.Join(msgs, "; ")
+	if e.BID != "" {
+		text = "batch item " + e.BID + " " + text
+	}
+	return text
+}
+
+// Is reports whether any fault of the batch item maps to target.
+func (e BatchError) Is(target error) bool {
+	for _, f := range e.Faults {
+		if err := f.Code.Err(); err != nil && err == target {
+			return true
+		}
+	}
+	return false
 }
 
+// BatchItemRequest is a single operation of a batch request. It holds either
+// a Query or exactly one entity payload, which BatchCreate, BatchUpdate,
+// BatchDelete and BatchVoid set for you.
 type BatchItemRequest struct {
-	BID         string          `json:"bId"`
-	OptionsData BatchOptions    `json:"optionsData,omitempty"`
-	Operation   BatchOperations `json:"operation,omitempty"`
-	Query       string          `json:",omitempty"`
+	BID             string           `json:"bId"`
+	OptionsData     BatchOptions     `json:"optionsData,omitempty"`
+	Operation       BatchOperations  `json:"operation,omitempty"`
+	Query           string           `json:",omitempty"`
+	Account         *Account         `json:",omitempty"`
+	Attachable      *Attachable      `json:",omitempty"`
+	Bill            *Bill            `json:",omitempty"`
+	BillPayment     *BillPayment     `json:",omitempty"`
+	Class           *Class           `json:",omitempty"`
+	CreditMemo      *CreditMemo      `json:",omitempty"`
+	Customer        *Customer        `json:",omitempty"`
+	CustomerType    *CustomerType    `json:",omitempty"`
+	Deposit         *Deposit         `json:",omitempty"`
+	Employee        *Employee        `json:",omitempty"`
+	Estimate        *Estimate        `json:",omitempty"`
+	Invoice         *Invoice         `json:",omitempty"`
+	Item            *Item            `json:",omitempty"`
+	JournalEntry    *JournalEntry    `json:",omitempty"`
+	Payment         *Payment         `json:",omitempty"`
+	PaymentMethod   *PaymentMethod   `json:",omitempty"`
+	Purchase        *Purchase        `json:",omitempty"`
+	PurchaseOrder   *PurchaseOrder   `json:",omitempty"`
+	RefundReceipt   *RefundReceipt   `json:",omitempty"`
+	ReimburseCharge *ReimburseCharge `json:",omitempty"`
+	SalesReceipt    *SalesReceipt    `json:",omitempty"`
+	TaxCode         *TaxCode         `json:",omitempty"`
+	TaxRate         *TaxRate         `json:",omitempty"`
+	Term            *Term            `json:",omitempty"`
+	TimeActivity    *TimeActivity    `json:",omitempty"`
+	Transfer        *Transfer        `json:",omitempty"`
+	Vendor          *Vendor          `json:",omitempty"`
+	VendorCredit    *VendorCredit    `json:",omitempty"`
+	// sparse marks the entity payload of an update as a sparse update.
+	sparse bool
+}
+
+// UpdateMode selects between a full and a sparse update in BatchUpdate.
+type UpdateMode int
+
+const (
+	// Full replaces the entity, clearing writable fields left empty.
+	Full UpdateMode = iota
+	// Sparse only changes the fields set on the entity.
+	Sparse
+)
+
+// BatchQuery returns a batch item running the given query.
+func BatchQuery(bid, query string) BatchItemRequest {
+	return BatchItemRequest{BID: bid, Query: query}
+}
+
+// BatchCreate returns a batch item creating entity.
+func BatchCreate[T Entity](bid string, entity *T) BatchItemRequest {
+	return newBatchItem(bid, Create, entity)
+}
+
+// BatchUpdate returns a batch item updating entity, which must carry its Id
+// and current SyncToken.
+func BatchUpdate[T Entity](bid string, entity *T, mode UpdateMode) BatchItemRequest {
+	item := newBatchItem(bid, Update, entity)
+	item.sparse = mode == Sparse
+	return item
+}
+
+// BatchDelete returns a batch item deleting entity, which must carry its Id
+// and current SyncToken.
+func BatchDelete[T Entity](bid string, entity *T) BatchItemRequest {
+	return newBatchItem(bid, Delete, entity)
+}
+
+// BatchVoid returns a batch item voiding the transaction entity, which must
+// carry its Id and current SyncToken.
+func BatchVoid[T Entity](bid string, entity *T) BatchItemRequest {
+	item := newBatchItem(bid, Update, entity)
+	item.OptionsData = Void
+	return item
+}
+
+func newBatchItem[T Entity](bid string, operation BatchOperations, entity *T) BatchItemRequest {
+	item := BatchItemRequest{BID: bid, Operation: operation}
+	if entity != nil {
+		reflect.ValueOf(&item).Elem().FieldByName(EntityName[T]()).Set(reflect.ValueOf(entity))
+	}
+	return item
+}
+
+// payload returns the name and value of the entity set on the item. It
+// fails if more than one is set.
+func (r BatchItemRequest) payload() (string, reflect.Value, error) {
+	var name string
+	var value reflect.Value
+
+	v := reflect.ValueOf(r)
+	for entityName := range entityTypes {
+		field := v.FieldByName(entityName)
+		if field.IsNil() {
+			continue
+		}
+		if name != "" {
+			return "", reflect.Value{}, fmt.Errorf("batch item %s has both a %s and a %s payload", r.BID, name, entityName)
+		}
+		name, value = entityName, field
+	}
+
+	return name, value, nil
+}
+
+// validate checks that the item is either a query or an operation on a
+// single entity.
+func (r BatchItemRequest) validate() error {
+	name, _, err := r.payload()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case r.BID == "":
+		return errors.New("batch item is missing a bId")
+	case r.Query != "" && name != "":
+		return fmt.Errorf("batch item %s has both a query and a %s payload", r.BID, name)
+	case r.Query == "" && name == "":
+		return fmt.Errorf("batch item %s has neither a query nor an entity payload", r.BID)
+	case name != "" && r.Operation == "":
+		return fmt.Errorf("batch item %s is missing an operation", r.BID)
+	}
+
+	return nil
+}
+
+// validateEntities makes the checks CreateJournalEntry, UpdateJournalEntry,
+// CreateTransfer and UpdateTransfer make on the journal entries and
+// transfers the items create or update. The accounts of every journal entry
+// are looked up in a single query.
+func (c *Client) validateEntities(params RequestParameters, batchRequests []BatchItemRequest) error {
+	var journalEntries []*JournalEntry
+	var journalEntryBIDs []string
+
+	for _, item := range batchRequests {
+		if (item.Operation != Create && item.Operation != Update) || item.OptionsData == Void {
+			continue
+		}
+
+		if item.JournalEntry != nil && (!item.sparse || len(item.JournalEntry.Line) > 0) {
+			journalEntries = append(journalEntries, item.JournalEntry)
+			journalEntryBIDs = append(journalEntryBIDs, item.BID)
+		}
+
+		if item.Transfer != nil && (!item.sparse || item.Transfer.FromAccountRef.Value != "" || item.Transfer.ToAccountRef.Value != "") {
+			if err := c.validateTransfer(params, item.Transfer); err != nil {
+				return fmt.Errorf("batch item %s: %w", item.BID, err)
+			}
+		}
+	}
+
+	if len(journalEntries) > 0 {
+		if i, err := c.validateJournalEntries(params, journalEntries); err != nil {
+			return fmt.Errorf("batch item %s: %w", journalEntryBIDs[i], err)
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON adds the sparse flag to the entity of a sparse update.
+func (r BatchItemRequest) MarshalJSON() ([]byte, error) {
+	type batchItemRequest BatchItemRequest
+
+	data, err := json.Marshal(batchItemRequest(r))
+	if err != nil || !r.sparse {
+		return data, err
+	}
+
+	name, _, err := r.payload()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return data, nil
+	}
+
+	var item map[string]json.RawMessage
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, err
+	}
+
+	if item[name], err = markSparse(item[name]); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(item)
 }
 
 type BatchFaultResponse struct {
@@ -65,14 +276,19 @@ type BatchQueryResponse struct {
 	Estimate        []Estimate        `json:",omitempty"`
 	Invoice         []Invoice         `json:",omitempty"`
 	Item            []Item            `json:",omitempty"`
+	JournalEntry    []JournalEntry    `json:",omitempty"`
 	Payment         []Payment         `json:",omitempty"`
 	PaymentMethod   []PaymentMethod   `json:",omitempty"`
 	Purchase        []Purchase        `json:",omitempty"`
+	PurchaseOrder   []PurchaseOrder   `json:",omitempty"`
+	RefundReceipt   []RefundReceipt   `json:",omitempty"`
 	ReimburseCharge []ReimburseCharge `json:",omitempty"`
+	SalesReceipt    []SalesReceipt    `json:",omitempty"`
 	TaxCode         []TaxCode         `json:",omitempty"`
 	TaxRate         []TaxRate         `json:",omitempty"`
 	Term            []Term            `json:",omitempty"`
 	TimeActivity    []TimeActivity    `json:",omitempty"`
+	Transfer        []Transfer        `json:",omitempty"`
 	Vendor          []Vendor          `json:",omitempty"`
 	VendorCredit    []VendorCredit    `json:",omitempty"`
 	StartPosition   int               `json:"startPosition"`
@@ -95,58 +311,396 @@ type BatchItemResponse struct {
 	Estimate        Estimate           `json:",omitempty"`
 	Invoice         Invoice            `json:",omitempty"`
 	Item            Item               `json:",omitempty"`
+	JournalEntry    JournalEntry       `json:",omitempty"`
 	Payment         Payment            `json:",omitempty"`
 	PaymentMethod   PaymentMethod      `json:",omitempty"`
 	Purchase        Purchase           `json:",omitempty"`
+	PurchaseOrder   PurchaseOrder      `json:",omitempty"`
+	RefundReceipt   RefundReceipt      `json:",omitempty"`
 	ReimburseCharge ReimburseCharge    `json:",omitempty"`
+	SalesReceipt    SalesReceipt       `json:",omitempty"`
 	TaxCode         TaxCode            `json:",omitempty"`
 	TaxRate         TaxRate            `json:",omitempty"`
 	Term            Term               `json:",omitempty"`
 	TimeActivity    TimeActivity       `json:",omitempty"`
+	Transfer        Transfer           `json:",omitempty"`
 	Vendor          Vendor             `json:",omitempty"`
 	VendorCredit    VendorCredit       `json:",omitempty"`
 	Fault           BatchFaultResponse `json:",omitempty"`
 	QueryResponse   BatchQueryResponse `json:"QueryResponse,omitempty"`
+
+	// entityName is the entity present in the response, if any.
+	entityName string
+	// hasQueryResponse is set when the response holds a QueryResponse.
+	hasQueryResponse bool
+}
+
+// UnmarshalJSON records which entity the response holds, so it can be told
+// apart from a zero value.
+func (r *BatchItemResponse) UnmarshalJSON(data []byte) error {
+	type batchItemResponse BatchItemResponse
+
+	var item batchItemResponse
+	if err := json.Unmarshal(data, &item); err != nil {
+		return err
+	}
+
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+
+	*r = BatchItemResponse(item)
+	for key := range keys {
+		if _, ok := entityTypes[key]; ok {
+			r.entityName = key
+		}
+	}
+	_, r.hasQueryResponse = keys["QueryResponse"]
+
+	return nil
 }
 
+// EntityName returns the name of the entity held by the response, or an
+// empty string if it holds none.
+func (r *BatchItemResponse) EntityName() string {
+	return r.entityName
+}
+
+// Entity returns a pointer to the entity held by the response, or nil if it
+// holds none.
+func (r *BatchItemResponse) Entity() any {
+	if r.entityName == "" {
+		return nil
+	}
+	return reflect.ValueOf(r).Elem().FieldByName(r.entityName).Addr().Interface()
+}
+
+// Err returns the faults of the response as a BatchError, or nil if the
+// item succeeded.
+func (r *BatchItemResponse) Err() error {
+	if r.Fault.FaultType == "" && len(r.Fault.Faults) == 0 {
+		return nil
+	}
+	return BatchError{BID: r.BID, FaultType: r.Fault.FaultType, Faults: r.Fault.Faults}
+}
+
+// BatchRequest sends the items in batches of BatchSize and returns the raw
+// responses. ExecuteBatch correlates the responses with their requests.
+// When there is more than one batch, each is sent with params.RequestId
+// suffixed by "-" and the batch's index.
+//
+// If some chunks fail, the error is a *BatchChunkError and the responses of
+// the chunks that succeeded are returned with it. Journal entries and
+// transfers are validated as in CreateJournalEntry and CreateTransfer before
+// anything is sent.
 func (c *Client) BatchRequest(params RequestParameters, batchRequests []BatchItemRequest) (*[]BatchItemResponse, error) {
 	if len(batchRequests) == 0 {
 		return nil, nil
 	}
 
-	var allResponses []BatchItemResponse
+	for _, item := range batchRequests {
+		if err := item.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.validateEntities(params, batchRequests); err != nil {
+		return nil, err
+	}
+
+	allResponses, err := c.sendBatches(params, batchRequests)
+	if err != nil {
+		if allResponses == nil {
+			return nil, err
+		}
+		return &allResponses, err
+	}
+
+	return &allResponses, nil
+}
+
+// BatchSize is the maximum number of items QuickBooks accepts in a single
+// batch request.
+const BatchSize = 30
+
+// BatchChunk identifies the items[Start:End] slice sent as one batch call.
+type BatchChunk struct {
+	Index int
+	Start int
+	End   int
+	// Err is why the chunk failed, or ErrBatchChunkSkipped if it was never
+	// sent.
+	Err error
+}
+
+// ErrBatchChunkSkipped marks chunks that were not sent because an earlier
+// chunk failed.
+var ErrBatchChunkSkipped = errors.New("quickbooks: batch chunk skipped after an earlier failure")
+
+// BatchChunkError reports a batch whose chunks did not all complete. The
+// responses of the Succeeded chunks are still returned.
+type BatchChunkError struct {
+	Succeeded []BatchChunk
+	Failed    []BatchChunk
+}
 
-	// each BatchRequest is limited to 30 items
-	chunkSize := 30
-	for start := 0; start < len(batchRequests); start += chunkSize {
-		end := start + chunkSize
+func (e *BatchChunkError) Error() string {
+	msgs := make([]string, 0, len(e.Failed))
+	for _, chunk := range e.Failed {
+		msgs = append(msgs, fmt.Sprintf("items %d-%d: %v", chunk.Start, chunk.End-1, chunk.Err))
+	}
+	return fmt.Sprintf("failed to complete batch request: %d of %d chunks failed: %s",
+		len(e.Failed), len(e.Failed)+len(e.Succeeded), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed chunks.
+func (e *BatchChunkError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, chunk := range e.Failed {
+		errs[i] = chunk.Err
+	}
+	return errs
+}
+
+// sendBatches sends the items in chunks of BatchSize, up to
+// c.batchParallelism at a time. Responses are returned in chunk order. Once
+// a chunk fails no further chunks are started, and a *BatchChunkError lists
+// what was and was not sent along with the responses that did arrive.
+func (c *Client) sendBatches(params RequestParameters, batchRequests []BatchItemRequest) ([]BatchItemResponse, error) {
+	var chunks []BatchChunk
+	for start := 0; start < len(batchRequests); start += BatchSize {
+		end := start + BatchSize
 		if end > len(batchRequests) {
 			end = len(batchRequests)
 		}
-		batch := batchRequests[start:end]
+		chunks = append(chunks, BatchChunk{Index: len(chunks), Start: start, End: end})
+	}
 
-		var payload struct {
-			BatchItemRequest []BatchItemRequest `json:"BatchItemRequest"`
+	responses := make([][]BatchItemResponse, len(chunks))
+
+	var wg sync.WaitGroup
+	var failed atomic.Bool
+	slots := make(chan struct{}, c.batchParallelism)
+
+	for i := range chunks {
+		slots <- struct{}{}
+		if failed.Load() {
+			<-slots
+			chunks[i].Err = ErrBatchChunkSkipped
+			continue
 		}
 
-		var res struct {
-			BatchItemResponses []BatchItemResponse `json:"BatchItemResponse"`
-			Time               time.Time           `json:"time"`
+		wg.Add(1)
+		go func(chunk *BatchChunk) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			var payload struct {
+				BatchItemRequest []BatchItemRequest `json:"BatchItemRequest"`
+			}
+
+			var res struct {
+				BatchItemResponses []BatchItemResponse `json:"BatchItemResponse"`
+				Time               time.Time           `json:"time"`
+			}
+
+			payload.BatchItemRequest = batchRequests[chunk.Start:chunk.End]
+
+			// QuickBooks deduplicates on the request id, so every chunk
+			// needs its own or it gets the first chunk's response back.
+			chunkParams := params
+			if params.RequestId != "" && len(chunks) > 1 {
+				chunkParams.RequestId = params.RequestId + "-" + strconv.Itoa(chunk.Index)
+			}
+
+			if err := c.batch(chunkParams, payload, &res); err != nil {
+				chunk.Err = err
+				failed.Store(true)
+				return
+			}
+
+			responses[chunk.Index] = res.BatchItemResponses
+		}(&chunks[i])
+	}
+	wg.Wait()
+
+	var allResponses []BatchItemResponse
+	chunkErr := &BatchChunkError{}
+
+	for _, chunk := range chunks {
+		if chunk.Err != nil {
+			chunkErr.Failed = append(chunkErr.Failed, chunk)
+			continue
 		}
+		chunkErr.Succeeded = append(chunkErr.Succeeded, chunk)
+		allResponses = append(allResponses, responses[chunk.Index]...)
+	}
+
+	if len(chunkErr.Failed) > 0 {
+		if len(chunks) == 1 {
+			return nil, fmt.Errorf("failed to complete batch request: %w", chunkErr.Failed[0].Err)
+		}
+		return allResponses, chunkErr
+	}
 
-		payload.BatchItemRequest = batch
+	return allResponses, nil
+}
+
+// BatchItemResult is the outcome of a single batch item.
+type BatchItemResult struct {
+	BID     string
+	Request BatchItemRequest
+	// Response is the raw response, nil if QuickBooks did not answer the item.
+	Response *BatchItemResponse
+	// Entity points to the created, updated, deleted or voided entity.
+	Entity any
+	// QueryResponse holds the results of a query item.
+	QueryResponse *BatchQueryResponse
+	// Err is a BatchError when QuickBooks faulted the item.
+	Err error
+}
+
+// Failed reports whether the item did not succeed.
+func (r *BatchItemResult) Failed() bool {
+	return r.Err != nil
+}
 
-		err := c.batch(params, payload, &res)
-		if err != nil {
-			return nil, fmt.Errorf("failed to complete batch request: %w", err)
+// BatchResult holds the outcome of every item of a batch, keyed by bId.
+type BatchResult struct {
+	items map[string]*BatchItemResult
+	order []string
+}
+
+// Get returns the result of the item with the given bId.
+func (r *BatchResult) Get(bid string) (*BatchItemResult, bool) {
+	item, ok := r.items[bid]
+	return item, ok
+}
+
+// Items returns the results in the order the items were requested.
+func (r *BatchResult) Items() []*BatchItemResult {
+	items := make([]*BatchItemResult, len(r.order))
+	for i, bid := range r.order {
+		items[i] = r.items[bid]
+	}
+	return items
+}
+
+// Failed returns the results of the items that did not succeed, in request
+// order.
+func (r *BatchResult) Failed() []*BatchItemResult {
+	var failed []*BatchItemResult
+	for _, bid := range r.order {
+		if item := r.items[bid]; item.Failed() {
+			failed = append(failed, item)
 		}
+	}
+	return failed
+}
 
-		allResponses = append(allResponses, res.BatchItemResponses...)
+// FailedRequests returns the requests of the items that did not succeed,
+// ready to be fixed up and resubmitted.
+func (r *BatchResult) FailedRequests() []BatchItemRequest {
+	var requests []BatchItemRequest
+	for _, item := range r.Failed() {
+		requests = append(requests, item.Request)
 	}
+	return requests
+}
 
-	return &allResponses, nil
+// Err joins the errors of the failed items, or returns nil if every item
+// succeeded.
+func (r *BatchResult) Err() error {
+	var errs []error
+	for _, item := range r.Failed() {
+		errs = append(errs, item.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// BatchEntity returns the entity of type T held by the item result.
+func BatchEntity[T Entity](item *BatchItemResult) (*T, bool) {
+	entity, ok := item.Entity.(*T)
+	return entity, ok
+}
+
+// ExecuteBatch sends the items in batches of BatchSize and maps every
+// response back to its request by bId. bIds must be unique across all
+// items. Faulted items are reported by the BatchResult rather than the
+// returned error. When only some chunks fail, the result is returned along
+// with a *BatchChunkError, and the items of the failed chunks carry its
+// error so they show up in FailedRequests. Journal entries and transfers are
+// validated as in CreateJournalEntry and CreateTransfer before anything is
+// sent.
+func (c *Client) ExecuteBatch(params RequestParameters, batchRequests []BatchItemRequest) (*BatchResult, error) {
+	result := &BatchResult{
+		items: make(map[string]*BatchItemResult, len(batchRequests)),
+		order: make([]string, 0, len(batchRequests)),
+	}
+
+	for _, item := range batchRequests {
+		if err := item.validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := result.items[item.BID]; ok {
+			return nil, fmt.Errorf("duplicate batch item bId %s", item.BID)
+		}
+		result.items[item.BID] = &BatchItemResult{BID: item.BID, Request: item}
+		result.order = append(result.order, item.BID)
+	}
+
+	if len(batchRequests) == 0 {
+		return result, nil
+	}
+	if err := c.validateEntities(params, batchRequests); err != nil {
+		return nil, err
+	}
+
+	responses, err := c.sendBatches(params, batchRequests)
+	var chunkErr *BatchChunkError
+	if err != nil && !errors.As(err, &chunkErr) {
+		return nil, err
+	}
+
+	if chunkErr != nil {
+		for _, chunk := range chunkErr.Failed {
+			for _, item := range batchRequests[chunk.Start:chunk.End] {
+				result.items[item.BID].Err = fmt.Errorf("batch item %s: %w", item.BID, chunk.Err)
+			}
+		}
+	}
+
+	for i := range responses {
+		resp := &responses[i]
+
+		item, ok := result.items[resp.BID]
+		if !ok {
+			return nil, fmt.Errorf("batch response for unknown bId %s", resp.BID)
+		}
+
+		item.Response = resp
+		item.Entity = resp.Entity()
+		if resp.hasQueryResponse {
+			item.QueryResponse = &resp.QueryResponse
+		}
+		item.Err = resp.Err()
+	}
+
+	for _, item := range result.items {
+		if item.Response == nil && item.Err == nil {
+			item.Err = fmt.Errorf("batch item %s: no response from QuickBooks", item.BID)
+		}
+	}
+
+	if chunkErr != nil {
+		return result, chunkErr
+	}
+
+	return result, nil
 }
 
+// Deprecated: use ExecuteBatch and BatchEntity, which tell a missing entity
+// from a zero one.
 func BatchEntityExtractor[T any](
 	resp *BatchItemResponse,
 	getEntity func(BatchItemResponse) T,
@@ -159,6 +713,7 @@ func BatchEntityExtractor[T any](
 	return zero, false
 }
 
+// Deprecated: use ExecuteBatch and BatchItemResult.QueryResponse.
 func BatchQueryExtractor[T any](
 	resp *BatchItemResponse,
 	getSlice func(BatchQueryResponse) []T,
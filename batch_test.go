@@ -0,0 +1,252 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchItemPayloads(t *testing.T) {
+	invoice := &Invoice{Id: "130", SyncToken: "2", DocNumber: "1001"}
+	customer := &Customer{Id: "58", SyncToken: "0", DisplayName: "Craig's Design"}
+
+	items := []BatchItemRequest{
+		BatchCreate("1", &Invoice{DocNumber: "1002"}),
+		BatchUpdate("2", customer, Sparse),
+		BatchUpdate("3", customer, Full),
+		BatchDelete("4", invoice),
+		BatchVoid("5", invoice),
+		BatchQuery("6", "SELECT * FROM Vendor"),
+	}
+
+	data, err := json.Marshal(items)
+	require.NoError(t, err)
+
+	var decoded []map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, "create", decoded[0]["operation"])
+	assert.Equal(t, "1002", decoded[0]["Invoice"].(map[string]interface{})["DocNumber"])
+
+	assert.Equal(t, "update", decoded[1]["operation"])
+	assert.Equal(t, true, decoded[1]["Customer"].(map[string]interface{})["sparse"])
+	assert.Equal(t, "Craig's Design", decoded[1]["Customer"].(map[string]interface{})["DisplayName"])
+	assert.NotContains(t, decoded[2]["Customer"], "sparse")
+
+	assert.Equal(t, "delete", decoded[3]["operation"])
+	assert.Equal(t, "130", decoded[3]["Invoice"].(map[string]interface{})["Id"])
+
+	assert.Equal(t, "update", decoded[4]["operation"])
+	assert.Equal(t, "void", decoded[4]["optionsData"])
+
+	assert.Equal(t, "SELECT * FROM Vendor", decoded[5]["Query"])
+	assert.NotContains(t, decoded[5], "Invoice")
+}
+
+func TestBatchRequestSendsPayloads(t *testing.T) {
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			BatchItemRequest []struct {
+				BID       string `json:"bId"`
+				Operation string `json:"operation"`
+				Customer  struct{ DisplayName string }
+			}
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		require.Len(t, payload.BatchItemRequest, 1)
+		assert.Equal(t, "create", payload.BatchItemRequest[0].Operation)
+		assert.Equal(t, "O'Brien", payload.BatchItemRequest[0].Customer.DisplayName)
+		w.Write([]byte(`{"BatchItemResponse":[{"bId":"1","Customer":{"Id":"59","DisplayName":"O'Brien"}}]}`))
+	})
+
+	responses, err := client.BatchRequest(params, []BatchItemRequest{BatchCreate("1", &Customer{DisplayName: "O'Brien"})})
+	require.NoError(t, err)
+	require.Len(t, *responses, 1)
+	assert.Equal(t, "59", (*responses)[0].Customer.Id)
+
+	_, err = client.BatchRequest(params, []BatchItemRequest{{BID: "2", Operation: Create}})
+	assert.Error(t, err)
+}
+
+func TestBatchRateLimitFailsFast(t *testing.T) {
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"BatchItemResponse":[{"bId":"1","Customer":{"Id":"59"}}]}`))
+	})
+	params.WaitOnRateLimit = false
+
+	items := []BatchItemRequest{BatchCreate("1", &Customer{DisplayName: "Ada"})}
+	for range 5 {
+		_, err := client.BatchRequest(params, items)
+		require.NoError(t, err)
+	}
+
+	_, err := client.BatchRequest(params, items)
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, realmBatchRL, rateLimitErr.LimitType)
+	assert.Empty(t, client.globalConcurrent)
+}
+
+func TestExecuteBatchMapsResultsByBID(t *testing.T) {
+	var calls int
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		var payload struct {
+			BatchItemRequest []struct {
+				BID string `json:"bId"`
+			}
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		// Answer in reverse order to check correlation by bId.
+		var responses []string
+		for i := len(payload.BatchItemRequest) - 1; i >= 0; i-- {
+			bid := payload.BatchItemRequest[i].BID
+			switch bid {
+			case "7":
+				responses = append(responses, `{"bId":"7","Fault":{"type":"ValidationFault","Error":[{"Message":"Duplicate Name Exists Error","code":"6240"}]}}`)
+			case "31":
+				responses = append(responses, `{"bId":"31","QueryResponse":{"Vendor":[{"Id":"3"}],"startPosition":1,"maxResults":1}}`)
+			default:
+				responses = append(responses, `{"bId":"`+bid+`","Customer":{"Id":"`+bid+`"}}`)
+			}
+		}
+		w.Write([]byte(`{"BatchItemResponse":[` + strings.Join(responses, ",") + `]}`))
+	})
+
+	var items []BatchItemRequest
+	for i := 1; i <= 30; i++ {
+		items = append(items, BatchCreate(strconv.Itoa(i), &Customer{DisplayName: "Customer " + strconv.Itoa(i)}))
+	}
+	items = append(items, BatchQuery("31", "SELECT * FROM Vendor"))
+
+	result, err := client.ExecuteBatch(params, items)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Len(t, result.Items(), 31)
+	assert.Equal(t, "1", result.Items()[0].BID)
+
+	item, ok := result.Get("12")
+	require.True(t, ok)
+	customer, ok := BatchEntity[Customer](item)
+	require.True(t, ok)
+	assert.Equal(t, "12", customer.Id)
+
+	query, ok := result.Get("31")
+	require.True(t, ok)
+	require.NotNil(t, query.QueryResponse)
+	assert.Equal(t, "3", query.QueryResponse.Vendor[0].Id)
+
+	failed := result.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "7", failed[0].BID)
+	assert.ErrorIs(t, result.Err(), ErrDuplicateName)
+
+	var batchErr BatchError
+	require.ErrorAs(t, failed[0].Err, &batchErr)
+	assert.Equal(t, "ValidationFault", batchErr.FaultType)
+	assert.Equal(t, []BatchItemRequest{items[6]}, result.FailedRequests())
+
+	_, err = client.ExecuteBatch(params, []BatchItemRequest{BatchQuery("1", "SELECT * FROM Item"), BatchQuery("1", "SELECT * FROM Term")})
+	assert.Error(t, err)
+}
+
+func TestExecuteBatchSendsChunksConcurrently(t *testing.T) {
+	var active, peak atomic.Int32
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			BatchItemRequest []struct {
+				BID string `json:"bId"`
+			}
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		if payload.BatchItemRequest[0].BID == "90" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		n := active.Add(1)
+		defer active.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(30 * time.Millisecond)
+
+		var responses []string
+		for _, item := range payload.BatchItemRequest {
+			responses = append(responses, `{"bId":"`+item.BID+`","Customer":{"Id":"`+item.BID+`"}}`)
+		}
+		w.Write([]byte(`{"BatchItemResponse":[` + strings.Join(responses, ",") + `]}`))
+	})
+	client.batchParallelism = 4
+
+	var items []BatchItemRequest
+	for i := 0; i < 150; i++ {
+		items = append(items, BatchCreate(strconv.Itoa(i), &Customer{DisplayName: strconv.Itoa(i)}))
+	}
+
+	result, err := client.ExecuteBatch(params, items)
+	assert.Greater(t, peak.Load(), int32(1))
+
+	var chunkErr *BatchChunkError
+	require.ErrorAs(t, err, &chunkErr)
+	require.Len(t, chunkErr.Succeeded, 3)
+	assert.Equal(t, 2, chunkErr.Succeeded[2].Index)
+	require.Len(t, chunkErr.Failed, 2)
+	assert.Equal(t, 90, chunkErr.Failed[0].Start)
+	assert.ErrorIs(t, chunkErr.Failed[1].Err, ErrBatchChunkSkipped)
+
+	require.NotNil(t, result)
+	for i, item := range result.Items()[:90] {
+		require.NoError(t, item.Err)
+		assert.Equal(t, strconv.Itoa(i), item.Response.BID)
+	}
+	assert.Equal(t, items[90:], result.FailedRequests())
+}
+
+func TestExecuteBatchGivesChunksTheirOwnRequestId(t *testing.T) {
+	var mu sync.Mutex
+	var requestIds []string
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestIds = append(requestIds, r.URL.Query().Get("requestid"))
+		mu.Unlock()
+
+		var payload struct {
+			BatchItemRequest []struct {
+				BID string `json:"bId"`
+			}
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		var responses []string
+		for _, item := range payload.BatchItemRequest {
+			responses = append(responses, `{"bId":"`+item.BID+`","Customer":{"Id":"`+item.BID+`"}}`)
+		}
+		w.Write([]byte(`{"BatchItemResponse":[` + strings.Join(responses, ",") + `]}`))
+	})
+	params.RequestId = "abc"
+
+	var items []BatchItemRequest
+	for i := range 31 {
+		items = append(items, BatchCreate(strconv.Itoa(i), &Customer{DisplayName: strconv.Itoa(i)}))
+	}
+
+	_, err := client.ExecuteBatch(params, items)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"abc-0", "abc-1"}, requestIds)
+
+	requestIds = nil
+	_, err = client.ExecuteBatch(params, items[:30])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc"}, requestIds)
+}
@@ -16,9 +16,9 @@ type Bill struct {
 	DepartmentRef           *ReferenceType       `json:",omitempty"`
 	RecurDataRef            *ReferenceType       `json:",omitempty"`
 	TxnTaxDetail            *TxnTaxDetail        `json:",omitempty"`
-	MetaData                ModificationMetaData `json:",omitempty"`
-	TxnDate                 Date                 `json:",omitempty"`
-	DueDate                 Date                 `json:",omitempty"`
+	MetaData                ModificationMetaData `json:",omitzero"`
+	TxnDate                 Date                 `json:",omitzero"`
+	DueDate                 Date                 `json:",omitzero"`
 	TotalAmt                json.Number          `json:",omitempty"`
 	ExchangeRate            json.Number          `json:",omitempty"`
 	HomeBalance             json.Number          `json:",omitempty"`
@@ -33,6 +33,21 @@ type Bill struct {
 	// IncludeInAnnualTPAR  bool          `json:",omitempty"`
 	// GlobalTaxCalculation
 	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that Bill does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a Bill, keeping the fields it does not model in Unknown.
+func (b *Bill) UnmarshalJSON(data []byte) error {
+	type bill Bill
+	return unmarshalWithUnknown(data, (*bill)(b), &b.Unknown)
+}
+
+// MarshalJSON encodes a Bill along with its Unknown fields.
+func (b Bill) MarshalJSON() ([]byte, error) {
+	type bill Bill
+	return marshalWithUnknown(bill(b), b.Unknown)
 }
 
 // CreateBill creates the given Bill on the QuickBooks server, returning
@@ -61,36 +76,7 @@ func (c *Client) DeleteBill(params RequestParameters, bill *Bill) error {
 
 // FindBills gets the full list of Bills in the QuickBooks account.
 func (c *Client) FindBills(params RequestParameters) ([]Bill, error) {
-	var resp struct {
-		QueryResponse struct {
-			Bills         []Bill `json:"Bill"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Bill", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	bills := make([]Bill, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Bill ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		bills = append(bills, resp.QueryResponse.Bills...)
-	}
-
-	return bills, nil
+	return queryAll[Bill](c, params, "")
 }
 
 func (c *Client) FindBillsByPage(params RequestParameters, startPosition, pageSize int) ([]Bill, error) {
@@ -127,7 +113,7 @@ func (c *Client) FindBillById(params RequestParameters, id string) (*Bill, error
 }
 
 // QueryBills accepts an SQL query and returns all bills found using it
-func (c *Client) QueryBills(params RequestParameters, query string) ([]Bill, error) {
+func (c *Client) QueryBills(params RequestParameters, query QueryStatement) ([]Bill, error) {
 	var resp struct {
 		QueryResponse struct {
 			Bills         []Bill `json:"Bill"`
@@ -136,7 +122,7 @@ func (c *Client) QueryBills(params RequestParameters, query string) ([]Bill, err
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -149,12 +135,14 @@ func (c *Client) UpdateBill(params RequestParameters, bill *Bill) (*Bill, error)
 		return nil, errors.New("missing bill id")
 	}
 
-	existingBill, err := c.FindBillById(params, bill.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingBill, err := c.FindBillById(params, bill.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	bill.SyncToken = existingBill.SyncToken
+		bill.SyncToken = existingBill.SyncToken
+	}
 
 	payload := struct {
 		*Bill
@@ -167,9 +155,9 @@ func (c *Client) UpdateBill(params RequestParameters, bill *Bill) (*Bill, error)
 		Time Date
 	}
 
-	if err = c.post(params, "bill", payload, &billData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "bill", payload, &billData, nil); err != nil {
+		return nil, conflictError(params, bill.Id, bill, err, c.FindBillById)
 	}
 
-	return &billData.Bill, err
+	return &billData.Bill, nil
 }
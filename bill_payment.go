@@ -39,8 +39,8 @@ type BillPayment struct {
 	DepartmentRef      *ReferenceType        `json:",omitempty"`
 	CheckPayment       BillPaymentCheck      `json:",omitempty"`
 	CreditCardPayment  BillPaymentCreditCard `json:",omitempty"`
-	TxnDate            Date                  `json:",omitempty"`
-	MetaData           ModificationMetaData  `json:",omitempty"`
+	TxnDate            Date                  `json:",omitzero"`
+	MetaData           ModificationMetaData  `json:",omitzero"`
 	TotalAmt           json.Number
 	ExchangeRate       json.Number `json:",omitempty"`
 	PayType            BillPaymentTypeEnum
@@ -52,6 +52,21 @@ type BillPayment struct {
 	Domain             string `json:"domain,omitempty"`
 	Status             string `json:"status,omitempty"`
 	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that BillPayment does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a BillPayment, keeping the fields it does not model in Unknown.
+func (b *BillPayment) UnmarshalJSON(data []byte) error {
+	type billPayment BillPayment
+	return unmarshalWithUnknown(data, (*billPayment)(b), &b.Unknown)
+}
+
+// MarshalJSON encodes a BillPayment along with its Unknown fields.
+func (b BillPayment) MarshalJSON() ([]byte, error) {
+	type billPayment BillPayment
+	return marshalWithUnknown(billPayment(b), b.Unknown)
 }
 
 // CreateBillPayment creates the given Bill on the QuickBooks server, returning
@@ -80,36 +95,7 @@ func (c *Client) DeleteBillPayment(params RequestParameters, billPayment *BillPa
 
 // FindBills gets the full list of Bills in the QuickBooks account.
 func (c *Client) FindBillPayments(params RequestParameters) ([]BillPayment, error) {
-	var resp struct {
-		QueryResponse struct {
-			BillPayments  []BillPayment `json:"BillPayment"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM BillPayments", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	billPayments := make([]BillPayment, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM BillPayment ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		billPayments = append(billPayments, resp.QueryResponse.BillPayments...)
-	}
-
-	return billPayments, nil
+	return queryAll[BillPayment](c, params, "")
 }
 
 func (c *Client) FindBillPaymentsByPage(params RequestParameters, startPosition, pageSize int) ([]BillPayment, error) {
@@ -146,7 +132,7 @@ func (c *Client) FindBillPaymentById(params RequestParameters, id string) (*Bill
 }
 
 // QueryBills accepts an SQL query and returns all bills found using it
-func (c *Client) QueryBillPayments(params RequestParameters, query string) ([]BillPayment, error) {
+func (c *Client) QueryBillPayments(params RequestParameters, query QueryStatement) ([]BillPayment, error) {
 	var resp struct {
 		QueryResponse struct {
 			BillPayments  []BillPayment `json:"BillPayment"`
@@ -155,7 +141,7 @@ func (c *Client) QueryBillPayments(params RequestParameters, query string) ([]Bi
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -168,12 +154,14 @@ func (c *Client) UpdateBillPayment(params RequestParameters, billPayment *BillPa
 		return nil, errors.New("missing bill payment id")
 	}
 
-	existingBillPayment, err := c.FindBillPaymentById(params, billPayment.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingBillPayment, err := c.FindBillPaymentById(params, billPayment.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	billPayment.SyncToken = existingBillPayment.SyncToken
+		billPayment.SyncToken = existingBillPayment.SyncToken
+	}
 
 	payload := struct {
 		*BillPayment
@@ -186,11 +174,11 @@ func (c *Client) UpdateBillPayment(params RequestParameters, billPayment *BillPa
 		Time        Date
 	}
 
-	if err = c.post(params, "billpayment", payload, &billPaymentData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "billpayment", payload, &billPaymentData, nil); err != nil {
+		return nil, conflictError(params, billPayment.Id, billPayment, err, c.FindBillPaymentById)
 	}
 
-	return &billPaymentData.BillPayment, err
+	return &billPaymentData.BillPayment, nil
 }
 
 func (c *Client) VoidBillPayment(params RequestParameters, billPayment BillPayment) error {
@@ -35,14 +35,14 @@ func TestBill(t *testing.T) {
 	assert.Equal(t, "33", r.Bill.APAccountRef.Value)
 	assert.Equal(t, "Norton Lumber and Building Materials", r.Bill.VendorRef.Name)
 	assert.Equal(t, "46", r.Bill.VendorRef.Value)
-	assert.Equal(t, "2014-11-06T00:00:00+00:00", r.Bill.TxnDate.String())
+	assert.Equal(t, "2014-11-06", r.Bill.TxnDate.String())
 	totalAmt, _ := r.Bill.TotalAmt.Float64()
 	assert.Equal(t, 103.55, totalAmt)
 	assert.Equal(t, "United States Dollar", r.Bill.CurrencyRef.Name)
 	assert.Equal(t, "USD", r.Bill.CurrencyRef.Value)
 	// LinkedTxn
 	assert.Equal(t, "3", r.Bill.SalesTermRef.Value)
-	assert.Equal(t, "2014-12-06T00:00:00+00:00", r.Bill.DueDate.String())
+	assert.Equal(t, "2014-12-06", r.Bill.DueDate.String())
 	assert.Equal(t, 1, len(r.Bill.Line))
 	balance, _ := r.Bill.Balance.Int64()
 	assert.Equal(t, int64(0), balance)
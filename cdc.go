@@ -12,6 +12,7 @@ type CDCQueryResponse struct {
 	Bill            []Bill            `json:",omitempty"`
 	BillPayment     []BillPayment     `json:",omitempty"`
 	Class           []Class           `json:",omitempty"`
+	CreditMemo      []CreditMemo      `json:",omitempty"`
 	Customer        []Customer        `json:",omitempty"`
 	CustomerType    []CustomerType    `json:",omitempty"`
 	Deposit         []Deposit         `json:",omitempty"`
@@ -19,11 +20,19 @@ type CDCQueryResponse struct {
 	Estimate        []Estimate        `json:",omitempty"`
 	Invoice         []Invoice         `json:",omitempty"`
 	Item            []Item            `json:",omitempty"`
+	JournalEntry    []JournalEntry    `json:",omitempty"`
 	Payment         []Payment         `json:",omitempty"`
 	PaymentMethod   []PaymentMethod   `json:",omitempty"`
 	Purchase        []Purchase        `json:",omitempty"`
+	PurchaseOrder   []PurchaseOrder   `json:",omitempty"`
+	RefundReceipt   []RefundReceipt   `json:",omitempty"`
 	ReimburseCharge []ReimburseCharge `json:",omitempty"`
+	SalesReceipt    []SalesReceipt    `json:",omitempty"`
+	TaxCode         []TaxCode         `json:",omitempty"`
+	TaxRate         []TaxRate         `json:",omitempty"`
 	Term            []Term            `json:",omitempty"`
+	TimeActivity    []TimeActivity    `json:",omitempty"`
+	Transfer        []Transfer        `json:",omitempty"`
 	Vendor          []Vendor          `json:",omitempty"`
 	VendorCredit    []VendorCredit    `json:",omitempty"`
 	StartPosition   int               `json:"startPosition"`
@@ -53,6 +62,8 @@ func (c *Client) ChangeDataCapture(params RequestParameters, entities []string,
 	return res, nil
 }
 
+// Deprecated: CDCQueryExtractor only returns the first non-empty slice it
+// finds and cannot tell deletions apart. Use ChangeFeed instead.
 func CDCQueryExtractor[T any](
 	res *ChangeDataCapture,
 	getSlice func(q CDCQueryResponse) []T,
@@ -0,0 +1,266 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CDCMaxResults is the most objects QuickBooks returns from a single change
+// data capture request. A response this large may have been truncated.
+const CDCMaxResults = 1000
+
+// CDCLookback is how far back change data capture can look.
+const CDCLookback = 30 * 24 * time.Hour
+
+// ErrCDCLookbackExceeded is returned when changedSince is older than
+// CDCLookback. The caller has to fall back to a full sync.
+var ErrCDCLookbackExceeded = errors.New("quickbooks: changedSince is beyond the 30 day change data capture lookback")
+
+// ChangeOp is the kind of change reported by ChangeFeed.
+type ChangeOp string
+
+const (
+	// ChangeUpsert is a created or updated object.
+	ChangeUpsert ChangeOp = "Upsert"
+	// ChangeDelete is a deleted object, of which only the Id and MetaData
+	// are known.
+	ChangeDelete ChangeOp = "Delete"
+)
+
+// Change is a single changed object.
+type Change struct {
+	// Entity is the QuickBooks name of the object's type, such as "Invoice".
+	Entity string
+	Id     string
+	Op     ChangeOp
+	// Object points to the decoded entity, such as *Invoice.
+	Object any
+	// LastUpdated is the object's MetaData.LastUpdatedTime.
+	LastUpdated time.Time
+}
+
+// ChangeFeedResult holds the changes since the requested time.
+type ChangeFeedResult struct {
+	// Changes are ordered by LastUpdated, each object appearing once with its
+	// latest state.
+	Changes []Change
+	// Checkpoint is the server time of the first request, to be passed as
+	// changedSince to the next ChangeFeed call. Changes made while the feed
+	// was read may be reported again by that call.
+	Checkpoint time.Time
+}
+
+// cdcEntities are the modeled entities the change data capture endpoint
+// supports. CustomerType, Attachable, ReimburseCharge, TaxCode and TaxRate
+// are not among them.
+var cdcEntities = []string{
+	"Account",
+	"Bill",
+	"BillPayment",
+	"Class",
+	"CreditMemo",
+	"Customer",
+	"Deposit",
+	"Employee",
+	"Estimate",
+	"Invoice",
+	"Item",
+	"JournalEntry",
+	"Payment",
+	"PaymentMethod",
+	"Purchase",
+	"PurchaseOrder",
+	"RefundReceipt",
+	"SalesReceipt",
+	"Term",
+	"TimeActivity",
+	"Transfer",
+	"Vendor",
+	"VendorCredit",
+}
+
+// CDCEntities returns the names of the entities ChangeFeed can request from
+// the change data capture endpoint.
+func CDCEntities() []string {
+	return slices.Clone(cdcEntities)
+}
+
+// ChangeFeed returns every change to the given entities since changedSince,
+// defaulting to all of CDCEntities. Responses that reach CDCMaxResults are
+// re-requested one entity at a time, and then from the latest change seen
+// until every change has been read.
+func (c *Client) ChangeFeed(params RequestParameters, entities []string, changedSince time.Time) (*ChangeFeedResult, error) {
+	if time.Since(changedSince) > CDCLookback {
+		return nil, ErrCDCLookbackExceeded
+	}
+
+	if len(entities) == 0 {
+		entities = CDCEntities()
+	}
+	for _, entity := range entities {
+		if !slices.Contains(cdcEntities, entity) {
+			return nil, fmt.Errorf("entity %s is not supported by the change feed", entity)
+		}
+	}
+
+	feed := changeFeed{changes: make(map[string]Change)}
+
+	checkpoint, count, err := feed.fetch(c, params, entities, changedSince)
+	if err != nil {
+		return nil, err
+	}
+
+	if count >= CDCMaxResults {
+		for _, entity := range entities {
+			if err := feed.drain(c, params, entity, changedSince); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := &ChangeFeedResult{Checkpoint: checkpoint}
+	for _, change := range feed.changes {
+		result.Changes = append(result.Changes, change)
+	}
+	slices.SortFunc(result.Changes, func(a, b Change) int {
+		if n := a.LastUpdated.Compare(b.LastUpdated); n != 0 {
+			return n
+		}
+		if n := strings.Compare(a.Entity, b.Entity); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	return result, nil
+}
+
+// changeFeed collects changes, keeping the latest one for every object.
+type changeFeed struct {
+	changes map[string]Change
+}
+
+// drain reads the changes to a single entity, advancing changedSince to the
+// latest change seen for as long as responses come back full.
+func (f *changeFeed) drain(c *Client, params RequestParameters, entity string, changedSince time.Time) error {
+	for {
+		_, count, err := f.fetch(c, params, []string{entity}, changedSince)
+		if err != nil {
+			return err
+		}
+		if count < CDCMaxResults {
+			return nil
+		}
+
+		latest := changedSince
+		for _, change := range f.changes {
+			if change.Entity == entity && change.LastUpdated.After(latest) {
+				latest = change.LastUpdated
+			}
+		}
+		if !latest.After(changedSince) {
+			return fmt.Errorf("more than %d %s changes at %s, cannot page change data capture", CDCMaxResults, entity, changedSince.Format(dateFormat))
+		}
+		changedSince = latest
+	}
+}
+
+// fetch makes one change data capture request, returning the server time
+// and the number of objects in the response.
+func (f *changeFeed) fetch(c *Client, params RequestParameters, entities []string, changedSince time.Time) (time.Time, int, error) {
+	var res struct {
+		CDCResponse []struct {
+			QueryResponse []map[string]json.RawMessage `json:"QueryResponse"`
+		} `json:"CDCResponse"`
+		Time string `json:"time"`
+	}
+
+	queryParams := map[string]string{
+		"entities":     strings.Join(entities, ","),
+		"changedSince": changedSince.Format(dateFormat),
+	}
+
+	if err := c.req(params, "GET", "cdc", nil, &res, queryParams); err != nil {
+		return time.Time{}, 0, fmt.Errorf("failed to make change data capture request: %w", err)
+	}
+
+	serverTime, err := time.Parse(time.RFC3339, res.Time)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("failed to parse change data capture time: %v", err)
+	}
+
+	count := 0
+	for _, resp := range res.CDCResponse {
+		for _, qr := range resp.QueryResponse {
+			for name, raw := range qr {
+				entityType, ok := entityTypes[name]
+				if !ok {
+					continue
+				}
+
+				var objects []json.RawMessage
+				if err := json.Unmarshal(raw, &objects); err != nil {
+					return time.Time{}, 0, fmt.Errorf("failed to decode %s changes: %v", name, err)
+				}
+
+				for _, object := range objects {
+					change, err := decodeChange(name, entityType, object)
+					if err != nil {
+						return time.Time{}, 0, err
+					}
+					f.add(change)
+				}
+				count += len(objects)
+			}
+		}
+	}
+
+	return serverTime, count, nil
+}
+
+func (f *changeFeed) add(change Change) {
+	key := change.Entity + "/" + change.Id
+	if existing, ok := f.changes[key]; ok && existing.LastUpdated.After(change.LastUpdated) {
+		return
+	}
+	f.changes[key] = change
+}
+
+func decodeChange(name string, entityType reflect.Type, object json.RawMessage) (Change, error) {
+	var header struct {
+		Id       string
+		Status   string `json:"status"`
+		MetaData struct {
+			LastUpdatedTime string
+		}
+	}
+	if err := json.Unmarshal(object, &header); err != nil {
+		return Change{}, fmt.Errorf("failed to decode %s change: %v", name, err)
+	}
+
+	change := Change{Entity: name, Id: header.Id, Op: ChangeUpsert}
+	if header.Status == "Deleted" {
+		change.Op = ChangeDelete
+	}
+
+	if header.MetaData.LastUpdatedTime != "" {
+		lastUpdated, err := time.Parse(time.RFC3339, header.MetaData.LastUpdatedTime)
+		if err != nil {
+			return Change{}, fmt.Errorf("failed to parse %s %s LastUpdatedTime: %v", name, header.Id, err)
+		}
+		change.LastUpdated = lastUpdated
+	}
+
+	value := reflect.New(entityType)
+	if err := json.Unmarshal(object, value.Interface()); err != nil {
+		return Change{}, fmt.Errorf("failed to decode %s %s: %v", name, header.Id, err)
+	}
+	change.Object = value.Interface()
+
+	return change, nil
+}
@@ -0,0 +1,111 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeFeedPagesTruncatedResponses(t *testing.T) {
+	base := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	serverTime := time.Now().Truncate(time.Second)
+
+	type object struct {
+		entity  string
+		id      string
+		updated time.Time
+		deleted bool
+	}
+	var objects []object
+	for i := 0; i < 1500; i++ {
+		objects = append(objects, object{entity: "Invoice", id: strconv.Itoa(1000 + i), updated: base.Add(time.Duration(i) * time.Second)})
+	}
+	objects = append(objects,
+		object{entity: "Customer", id: "1", updated: base.Add(time.Minute)},
+		object{entity: "Customer", id: "2", updated: base.Add(2 * time.Minute), deleted: true},
+	)
+
+	var requests []string
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		entities := strings.Split(r.URL.Query().Get("entities"), ",")
+		changedSince, err := time.Parse(dateFormat, r.URL.Query().Get("changedSince"))
+		require.NoError(t, err)
+		requests = append(requests, r.URL.Query().Get("entities"))
+
+		response := map[string][]map[string]interface{}{}
+		count := 0
+		for _, o := range objects {
+			if count == CDCMaxResults || o.updated.Before(changedSince) || !slices.Contains(entities, o.entity) {
+				continue
+			}
+			entry := map[string]interface{}{
+				"Id":       o.id,
+				"MetaData": map[string]string{"LastUpdatedTime": o.updated.Format(dateFormat)},
+			}
+			if o.deleted {
+				entry["status"] = "Deleted"
+			}
+			response[o.entity] = append(response[o.entity], entry)
+			count++
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"CDCResponse": []interface{}{map[string]interface{}{"QueryResponse": []interface{}{response}}},
+			"time":        serverTime.Format(time.RFC3339),
+		})
+	})
+
+	result, err := client.ChangeFeed(params, []string{"Customer", "Invoice"}, base)
+	require.NoError(t, err)
+
+	assert.True(t, serverTime.Equal(result.Checkpoint))
+	assert.Equal(t, []string{"Customer,Invoice", "Customer", "Invoice", "Invoice"}, requests)
+	require.Len(t, result.Changes, 1502)
+
+	var deleted []Change
+	for i, change := range result.Changes {
+		if i > 0 {
+			assert.False(t, change.LastUpdated.Before(result.Changes[i-1].LastUpdated))
+		}
+		if change.Op == ChangeDelete {
+			deleted = append(deleted, change)
+		}
+	}
+	require.Len(t, deleted, 1)
+	assert.Equal(t, "Customer", deleted[0].Entity)
+	assert.Equal(t, "2", deleted[0].Object.(*Customer).Id)
+
+	last := result.Changes[len(result.Changes)-1]
+	assert.Equal(t, "Invoice", last.Entity)
+	assert.IsType(t, &Invoice{}, last.Object)
+}
+
+func TestChangeFeedLookback(t *testing.T) {
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("no request expected")
+	})
+
+	_, err := client.ChangeFeed(params, nil, time.Now().Add(-31*24*time.Hour))
+	assert.ErrorIs(t, err, ErrCDCLookbackExceeded)
+
+	_, err = client.ChangeFeed(params, []string{"Budget"}, time.Now())
+	assert.Error(t, err)
+
+	_, err = client.ChangeFeed(params, []string{"Customer", "CustomerType"}, time.Now())
+	assert.EqualError(t, err, "entity CustomerType is not supported by the change feed")
+}
+
+func TestCDCEntitiesAreModeled(t *testing.T) {
+	for _, name := range CDCEntities() {
+		assert.Contains(t, entityTypes, name)
+	}
+	assert.NotContains(t, CDCEntities(), "CustomerType")
+	assert.Contains(t, CDCEntities(), "Transfer")
+}
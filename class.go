@@ -7,7 +7,7 @@ import (
 
 type Class struct {
 	ParentRef          ReferenceType        `json:",omitempty"`
-	MetaDate           ModificationMetaData `json:",omitempty"`
+	MetaDate           ModificationMetaData `json:",omitzero"`
 	Id                 string               `json:",omitempty"`
 	Name               string               `json:",omitempty"`
 	FullyQualifiedName string               `json:",omitempty"`
@@ -16,6 +16,21 @@ type Class struct {
 	Active             bool                 `json:",omitempty"`
 	Domain             string               `json:"domain,omitempty"`
 	Status             string               `json:"status,omitempty"`
+
+	// Unknown holds the fields QuickBooks sent that Class does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a Class, keeping the fields it does not model in Unknown.
+func (c *Class) UnmarshalJSON(data []byte) error {
+	type class Class
+	return unmarshalWithUnknown(data, (*class)(c), &c.Unknown)
+}
+
+// MarshalJSON encodes a Class along with its Unknown fields.
+func (c Class) MarshalJSON() ([]byte, error) {
+	type class Class
+	return marshalWithUnknown(class(c), c.Unknown)
 }
 
 // CreateClass creates the given Class on the QuickBooks server, returning
@@ -35,36 +50,7 @@ func (c *Client) CreateClass(params RequestParameters, class *Class) (*Class, er
 
 // FindClasss gets the full list of Classs in the QuickBooks account.
 func (c *Client) FindClasses(params RequestParameters) ([]Class, error) {
-	var resp struct {
-		QueryResponse struct {
-			Classes       []Class `json:"Class"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Class", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	classes := make([]Class, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Class ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		classes = append(classes, resp.QueryResponse.Classes...)
-	}
-
-	return classes, nil
+	return queryAll[Class](c, params, "")
 }
 
 func (c *Client) FindClassesByPage(params RequestParameters, startPosition, pageSize int) ([]Class, error) {
@@ -101,7 +87,7 @@ func (c *Client) FindClassById(params RequestParameters, id string) (*Class, err
 }
 
 // QueryClasss accepts an SQL query and returns all classs found using it
-func (c *Client) QueryClasses(params RequestParameters, query string) ([]Class, error) {
+func (c *Client) QueryClasses(params RequestParameters, query QueryStatement) ([]Class, error) {
 	var resp struct {
 		QueryResponse struct {
 			Classes       []Class `json:"Class"`
@@ -110,7 +96,7 @@ func (c *Client) QueryClasses(params RequestParameters, query string) ([]Class,
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -123,12 +109,14 @@ func (c *Client) UpdateClass(params RequestParameters, class *Class) (*Class, er
 		return nil, errors.New("missing class id")
 	}
 
-	existingClass, err := c.FindClassById(params, class.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingClass, err := c.FindClassById(params, class.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	class.SyncToken = existingClass.SyncToken
+		class.SyncToken = existingClass.SyncToken
+	}
 
 	payload := struct {
 		*Class
@@ -141,9 +129,9 @@ func (c *Client) UpdateClass(params RequestParameters, class *Class) (*Class, er
 		Time  Date
 	}
 
-	if err = c.post(params, "class", payload, &classData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "class", payload, &classData, nil); err != nil {
+		return nil, conflictError(params, class.Id, class, err, c.FindClassById)
 	}
 
-	return &classData.Class, err
+	return &classData.Class, nil
 }
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"golang.org/x/time/rate"
 )
@@ -113,22 +114,50 @@ func (m *RateLimiterManager) getRealmLimiter(realmId string) *RealmRateLimiters
 type Client struct {
 	Client            *http.Client
 	baseEndpoint      *url.URL
-	discoveryAPI      *DiscoveryAPI
+	discovery         *discoveryCache
+	jwks              *jwksCache
 	clientId          string
 	clientSecret      string
 	minorVersion      string
 	rateLimiter       *RateLimiterManager
 	globalConcurrent  chan struct{}
 	globalRateLimiter *rate.Limiter
+	retryPolicy       RetryPolicy
+	tokens            *TokenManager
+	batchParallelism  int
 }
 
 type ClientRequest struct {
-	Client       *http.Client
+	// Client sends every request, discovery included. Defaults to
+	// http.DefaultClient.
+	Client *http.Client
+	// Environment chooses the API endpoint and the discovery document, which
+	// is then fetched when first needed and cached. Endpoint and
+	// DiscoveryAPI, when set, must belong to the same environment.
+	Environment Environment
+	// DiscoveryAPI is used as is instead of fetching the discovery document.
 	DiscoveryAPI *DiscoveryAPI
 	ClientId     string
 	ClientSecret string
+	// Endpoint is the base URL of the API. Defaults to the Environment's.
 	Endpoint     string
 	MinorVersion string
+	// RetryPolicy enables automatic retries of 429 and 5xx responses.
+	// Requests are not retried when it is nil.
+	RetryPolicy *RetryPolicy
+	// TokenRefreshWindow is how long before expiry the TokenManager refreshes
+	// access tokens. Defaults to DefaultTokenRefreshWindow.
+	TokenRefreshWindow time.Duration
+	// TokenStore persists the TokenManager's tokens. Defaults to a
+	// MemoryTokenStore.
+	TokenStore TokenStore
+	// OnTokenRotated is called whenever a refresh rotates a realm's refresh
+	// token.
+	OnTokenRotated TokenRotatedFunc
+	// BatchParallelism is how many batch chunks are sent at once. Chunks
+	// still wait on the realm's batch limiter and concurrency semaphore.
+	// Defaults to 1, sending chunks one after another.
+	BatchParallelism int
 }
 
 // NewClient initializes a new QuickBooks client for interacting with their Online API
@@ -137,17 +166,46 @@ func NewClient(req ClientRequest) (c *Client, err error) {
 		req.MinorVersion = "75"
 	}
 
+	if req.Client == nil {
+		req.Client = http.DefaultClient
+	}
+
+	discovery := &discoveryCache{discovery: req.DiscoveryAPI, static: req.DiscoveryAPI != nil}
+
+	if req.Environment != "" {
+		if err := checkEnvironment(req.Environment, req.Endpoint, req.DiscoveryAPI); err != nil {
+			return nil, err
+		}
+		if req.Endpoint == "" {
+			req.Endpoint = req.Environment.Endpoint()
+		}
+		discovery.endpoint = req.Environment.DiscoveryEndpoint()
+	}
+
 	client := Client{
 		Client:            req.Client,
-		discoveryAPI:      req.DiscoveryAPI,
+		discovery:         discovery,
+		jwks:              &jwksCache{},
 		clientId:          req.ClientId,
 		clientSecret:      req.ClientSecret,
 		minorVersion:      req.MinorVersion,
 		rateLimiter:       NewRateLimiterManager(),
 		globalConcurrent:  make(chan struct{}, 10),
 		globalRateLimiter: rate.NewLimiter(rate.Limit(500.0/60.0), 10),
+		retryPolicy:       RetryPolicy{MaxAttempts: 1},
+		batchParallelism:  req.BatchParallelism,
+	}
+
+	if client.batchParallelism < 1 {
+		client.batchParallelism = 1
 	}
 
+	if req.RetryPolicy != nil {
+		client.retryPolicy = *req.RetryPolicy
+	}
+
+	client.tokens = newTokenManager(&client, req.TokenStore, req.OnTokenRotated, req.TokenRefreshWindow)
+
 	client.baseEndpoint, err = url.Parse(req.Endpoint + "/v3/company/")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse API endpoint: %v", err)
@@ -162,9 +220,18 @@ func NewClient(req ClientRequest) (c *Client, err error) {
 //
 // You can find live examples from https://developer.intuit.com/app/developer/playground
 func (c *Client) FindAuthorizationUrl(scope string, state string, redirectUri string) (string, error) {
-	var authorizationUrl *url.URL
+	return c.FindAuthorizationUrlWithNonce(scope, state, "", redirectUri)
+}
+
+// FindAuthorizationUrlWithNonce is FindAuthorizationUrl with a nonce, which
+// "Sign in with Intuit" puts in the ID token for VerifyIDToken to check.
+func (c *Client) FindAuthorizationUrlWithNonce(scope string, state string, nonce string, redirectUri string) (string, error) {
+	discovery, err := c.DiscoveryAPI(context.Background())
+	if err != nil {
+		return "", err
+	}
 
-	authorizationUrl, err := url.Parse(c.discoveryAPI.AuthorizationEndpoint)
+	authorizationUrl, err := url.Parse(discovery.AuthorizationEndpoint)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse auth endpoint: %v", err)
 	}
@@ -175,6 +242,9 @@ func (c *Client) FindAuthorizationUrl(scope string, state string, redirectUri st
 	urlValues.Add("scope", scope)
 	urlValues.Add("redirect_uri", redirectUri)
 	urlValues.Add("state", state)
+	if nonce != "" {
+		urlValues.Add("nonce", nonce)
+	}
 	authorizationUrl.RawQuery = urlValues.Encode()
 
 	return authorizationUrl.String(), nil
@@ -184,49 +254,81 @@ type RequestParameters struct {
 	Ctx             context.Context
 	WaitOnRateLimit bool
 	RealmId         string
-	Token           *BearerToken
+	// Token authorizes the request. When nil, the token is taken from the
+	// client's TokenManager.
+	Token *BearerToken
+	// RequestId is sent as the requestid query parameter, which QuickBooks
+	// uses to deduplicate writes. Writes are only retried when it is set.
+	RequestId string
+	// StrictConcurrency makes updates send the caller's SyncToken as is
+	// instead of the latest one on the server. An update based on a stale
+	// copy then fails with a *ConflictError rather than overwriting changes
+	// made since.
+	StrictConcurrency bool
+
+	// batch makes every attempt go through the realm's batch limiter as well.
+	batch bool
 }
 
-func (c *Client) req(params RequestParameters, method string, endpoint string, payloadData interface{}, responseObject interface{}, queryParameters map[string]string) error {
-	// 1. global concurrency semaphore
+// acquire takes a slot from every rate limiter that applies to the realm,
+// either waiting for it or failing fast depending on params.WaitOnRateLimit.
+// The returned function gives the concurrency slots back.
+func (c *Client) acquire(params RequestParameters) (func(), error) {
+	limiter := c.rateLimiter.getRealmLimiter(params.RealmId)
+
+	// 1. realm-batch rate limiter, checked before any slot is taken so that
+	// batches waiting on a throttled realm don't hold up other realms.
+	if params.batch {
+		if params.WaitOnRateLimit {
+			if err := limiter.batch.Wait(params.Ctx); err != nil {
+				return nil, fmt.Errorf("batch rate limiter error: %v", err)
+			}
+		} else {
+			if !limiter.batch.Allow() {
+				return nil, NewRateLimitError(realmBatchRL)
+			}
+		}
+	}
+
+	// 2. global concurrency semaphore
 	if params.WaitOnRateLimit {
 		select {
 		case c.globalConcurrent <- struct{}{}:
 		case <-params.Ctx.Done():
-			return params.Ctx.Err()
+			return nil, params.Ctx.Err()
 		}
-		defer func() { <-c.globalConcurrent }()
 	} else {
 		select {
 		case c.globalConcurrent <- struct{}{}:
-			defer func() { <-c.globalConcurrent }()
 		default:
-			return NewRateLimitError(globalConcurrentRL)
+			return nil, NewRateLimitError(globalConcurrentRL)
 		}
 	}
+	releaseGlobal := func() { <-c.globalConcurrent }
 
-	// 2. global rate limiter
+	// 3. global rate limiter
 	if params.WaitOnRateLimit {
 		if err := c.globalRateLimiter.Wait(params.Ctx); err != nil {
-			return fmt.Errorf("global rate limiter wait error: %v", err)
+			releaseGlobal()
+			return nil, fmt.Errorf("global rate limiter wait error: %v", err)
 		}
 	} else {
 		if !c.globalRateLimiter.Allow() {
-			return NewRateLimitError(globalGeneralRL)
+			releaseGlobal()
+			return nil, NewRateLimitError(globalGeneralRL)
 		}
 	}
 
-	// 3. retrieve the per-realm limiter.
-	limiter := c.rateLimiter.getRealmLimiter(params.RealmId)
-
 	// 4. realm-general rate limiter
 	if params.WaitOnRateLimit {
 		if err := limiter.general.Wait(params.Ctx); err != nil {
-			return fmt.Errorf("realm rate limiter wait error: %v", err)
+			releaseGlobal()
+			return nil, fmt.Errorf("realm rate limiter wait error: %v", err)
 		}
 	} else {
 		if !limiter.general.Allow() {
-			return NewRateLimitError(realmGeneralRL)
+			releaseGlobal()
+			return nil, NewRateLimitError(realmGeneralRL)
 		}
 	}
 
@@ -235,18 +337,80 @@ func (c *Client) req(params RequestParameters, method string, endpoint string, p
 		select {
 		case limiter.concurrent <- struct{}{}:
 		case <-params.Ctx.Done():
-			return params.Ctx.Err()
+			releaseGlobal()
+			return nil, params.Ctx.Err()
 		}
-		defer func() { <-limiter.concurrent }()
 	} else {
 		select {
 		case limiter.concurrent <- struct{}{}:
-			defer func() { <-limiter.concurrent }()
 		default:
-			return NewRateLimitError(realmConcurrentRL)
+			releaseGlobal()
+			return nil, NewRateLimitError(realmConcurrentRL)
 		}
 	}
 
+	return func() {
+		<-limiter.concurrent
+		releaseGlobal()
+	}, nil
+}
+
+// do sends the request built by newRequest, going through the rate limiters
+// on every attempt. When retryable is true, 429 and 5xx responses and
+// transport errors are retried according to the client's RetryPolicy.
+//
+// The rate limiter slots are held until the response body is closed.
+func (c *Client) do(params RequestParameters, retryable bool, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+
+		release, err := c.acquire(params)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.Client.Do(req)
+		if err != nil {
+			release()
+			if !retryable || params.Ctx.Err() != nil {
+				return nil, fmt.Errorf("failed to make request: %w", err)
+			}
+			wait, ok := c.retryPolicy.next(attempt, time.Since(start), 0)
+			if !ok {
+				return nil, fmt.Errorf("failed to make request: %w", err)
+			}
+			if err := sleep(params.Ctx, wait); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+
+		if !retryable || !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		wait, ok := c.retryPolicy.next(attempt, time.Since(start), parseRetryAfter(resp.Header))
+		if !ok {
+			return resp, nil
+		}
+
+		// Drain the body so the connection can be reused.
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleep(params.Ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) req(params RequestParameters, method string, endpoint string, payloadData interface{}, responseObject interface{}, queryParameters map[string]string) error {
 	// Build the full endpoint URL including realmId.
 	endpointUrl := *c.baseEndpoint
 	endpointUrl.Path += params.RealmId + "/" + endpoint
@@ -257,6 +421,9 @@ func (c *Client) req(params RequestParameters, method string, endpoint string, p
 		urlValues.Add(param, value)
 	}
 	urlValues.Set("minorversion", c.minorVersion)
+	if params.RequestId != "" {
+		urlValues.Set("requestid", params.RequestId)
+	}
 	endpointUrl.RawQuery = urlValues.Encode()
 
 	var marshalledJson []byte
@@ -268,19 +435,28 @@ func (c *Client) req(params RequestParameters, method string, endpoint string, p
 		}
 	}
 
-	req, err := http.NewRequestWithContext(params.Ctx, method, endpointUrl.String(), bytes.NewBuffer(marshalledJson))
+	accessToken, err := c.accessToken(params)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
+		return err
 	}
 
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Accept-Encoding", "gzip")
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+params.Token.AccessToken)
+	retryable := method == http.MethodGet || params.RequestId != ""
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(params, retryable, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(params.Ctx, method, endpointUrl.String(), bytes.NewReader(marshalledJson))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Add("Accept", "application/json")
+		req.Header.Add("Accept-Encoding", "gzip")
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("Authorization", "Bearer "+accessToken)
+
+		return req, nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to make request: %v", err)
+		return err
 	}
 	defer resp.Body.Close()
 
@@ -318,7 +494,6 @@ func (c *Client) req(params RequestParameters, method string, endpoint string, p
 func (c *Client) get(params RequestParameters, endpoint string, responseObject interface{}, queryParameters map[string]string) error {
 	return c.req(params, "GET", endpoint, nil, responseObject, queryParameters)
 }
-
 func (c *Client) post(params RequestParameters, endpoint string, payloadData interface{}, responseObject interface{}, queryParameters map[string]string) error {
 	return c.req(params, "POST", endpoint, payloadData, responseObject, queryParameters)
 }
@@ -328,11 +503,64 @@ func (c *Client) query(params RequestParameters, query string, responseObject in
 	return c.get(params, "query", responseObject, map[string]string{"query": query})
 }
 
-// batch handles batch requests. It waits on the batch limiter before sending.
-func (c *Client) batch(params RequestParameters, payloadData interface{}, responseObject interface{}) error {
-	limiter := c.rateLimiter.getRealmLimiter(params.RealmId)
-	if err := limiter.batch.Wait(params.Ctx); err != nil {
-		return fmt.Errorf("batch rate limiter error: %v", err)
+// queryStatement builds the statement and runs it with query.
+func (c *Client) queryStatement(params RequestParameters, statement QueryStatement, responseObject interface{}) error {
+	query, err := statement.Build()
+	if err != nil {
+		return err
 	}
+	return c.query(params, query, responseObject)
+}
+
+// batch handles batch requests. It waits on the batch limiter before every attempt.
+func (c *Client) batch(params RequestParameters, payloadData interface{}, responseObject interface{}) error {
+	params.batch = true
 	return c.post(params, "batch", payloadData, responseObject, nil)
 }
+
+// pdf downloads the PDF rendering of a transaction at endpoint, such as
+// "salesreceipt/123/pdf".
+func (c *Client) pdf(params RequestParameters, endpoint string) ([]byte, error) {
+	endpointUrl := *c.baseEndpoint
+	endpointUrl.Path += params.RealmId + "/" + endpoint
+
+	urlValues := url.Values{}
+	urlValues.Set("minorversion", c.minorVersion)
+	endpointUrl.RawQuery = urlValues.Encode()
+
+	accessToken, err := c.accessToken(params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(params, true, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(params.Ctx, http.MethodGet, endpointUrl.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Add("Accept", "application/pdf")
+		req.Header.Add("Authorization", "Bearer "+accessToken)
+
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusTooManyRequests:
+		return nil, NewRateLimitError(apiRl)
+	default:
+		return nil, parseFailure(resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pdf: %v", err)
+	}
+
+	return body, nil
+}
@@ -3,6 +3,11 @@
 
 package quickbooks
 
+import (
+	"errors"
+	"fmt"
+)
+
 // CompanyInfo describes a company account.
 type CompanyInfo struct {
 	CompanyName string
@@ -22,7 +27,22 @@ type CompanyInfo struct {
 	Domain    string
 	Id        string
 	SyncToken string
-	Metadata  ModificationMetaData `json:",omitempty"`
+	Metadata  ModificationMetaData `json:",omitzero"`
+
+	// Unknown holds the fields QuickBooks sent that CompanyInfo does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a CompanyInfo, keeping the fields it does not model in Unknown.
+func (c *CompanyInfo) UnmarshalJSON(data []byte) error {
+	type companyInfo CompanyInfo
+	return unmarshalWithUnknown(data, (*companyInfo)(c), &c.Unknown)
+}
+
+// MarshalJSON encodes a CompanyInfo along with its Unknown fields.
+func (c CompanyInfo) MarshalJSON() ([]byte, error) {
+	type companyInfo CompanyInfo
+	return marshalWithUnknown(companyInfo(c), c.Unknown)
 }
 
 // FindCompanyInfo returns the QuickBooks CompanyInfo object. This is a good
@@ -42,30 +62,50 @@ func (c *Client) FindCompanyInfo(params RequestParameters) (*CompanyInfo, error)
 
 // UpdateCompanyInfo updates the company info
 func (c *Client) UpdateCompanyInfo(params RequestParameters, companyInfo *CompanyInfo) (*CompanyInfo, error) {
-	existingCompanyInfo, err := c.FindCompanyInfo(params)
-	if err != nil {
-		return nil, err
-	}
-
-	companyInfo.Id = existingCompanyInfo.Id
-	companyInfo.SyncToken = existingCompanyInfo.SyncToken
+	if params.StrictConcurrency {
+		if companyInfo.Id == "" {
+			return nil, errors.New("missing company info id")
+		}
+	} else {
+		existingCompanyInfo, err := c.FindCompanyInfo(params)
+		if err != nil {
+			return nil, err
+		}
 
-	payload := struct {
-		*CompanyInfo
-		Sparse bool `json:"sparse"`
-	}{
-		CompanyInfo: companyInfo,
-		Sparse:      true,
+		companyInfo.Id = existingCompanyInfo.Id
+		companyInfo.SyncToken = existingCompanyInfo.SyncToken
 	}
 
+	payload := sparsePayload{companyInfo}
+
 	var companyInfoData struct {
 		CompanyInfo CompanyInfo
 		Time        Date
 	}
 
-	if err = c.post(params, "companyInfo", payload, &companyInfoData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "companyInfo", payload, &companyInfoData, nil); err != nil {
+		return nil, conflictError(params, companyInfo.Id, companyInfo, err, func(params RequestParameters, _ string) (*CompanyInfo, error) {
+			return c.FindCompanyInfo(params)
+		})
+	}
+
+	return &companyInfoData.CompanyInfo, nil
+}
+
+// homeCurrency returns the ISO 4217 code of the company's home currency,
+// from its preferences.
+func (c *Client) homeCurrency(params RequestParameters) (string, error) {
+	var resp struct {
+		Preferences struct {
+			CurrencyPrefs struct {
+				HomeCurrency ReferenceType
+			}
+		}
+	}
+
+	if err := c.get(params, "preferences", &resp, nil); err != nil {
+		return "", fmt.Errorf("failed to look up the home currency: %w", err)
 	}
 
-	return &companyInfoData.CompanyInfo, err
+	return resp.Preferences.CurrencyPrefs.HomeCurrency.Value, nil
 }
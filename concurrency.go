@@ -0,0 +1,155 @@
+package quickbooks
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ConflictError is returned by updates made with
+// RequestParameters.StrictConcurrency when the entity was changed on the
+// server after the caller read it. It wraps the stale object fault, so
+// errors.Is(err, ErrStaleObject) holds.
+type ConflictError struct {
+	// EntityName is the name of the entity type, such as "Invoice".
+	EntityName string
+	Id         string
+	// Server is the current copy of the entity on the server, a pointer such
+	// as *Invoice. It is nil when it could not be read, in which case Err
+	// says why.
+	Server interface{}
+	// Attempted is the rejected update, a pointer of the same type.
+	Attempted interface{}
+	Err       error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("quickbooks: %s %s was changed on the server: %v", e.EntityName, e.Id, e.Err)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// conflictError turns the stale object fault of a strict update of attempted
+// into a ConflictError holding the server's copy, read with find. Other
+// errors are returned as is.
+func conflictError[T any](params RequestParameters, id string, attempted *T, err error, find func(RequestParameters, string) (*T, error)) error {
+	if !params.StrictConcurrency || !errors.Is(err, ErrStaleObject) {
+		return err
+	}
+
+	conflict := &ConflictError{
+		EntityName: reflect.TypeFor[T]().Name(),
+		Id:         id,
+		Attempted:  attempted,
+		Err:        err,
+	}
+
+	server, findErr := find(params, id)
+	if findErr != nil {
+		conflict.Err = errors.Join(err, fmt.Errorf("failed to find %s %s: %w", conflict.EntityName, id, findErr))
+	} else {
+		conflict.Server = server
+	}
+
+	return conflict
+}
+
+// MergeConflictError is returned by Merge when both sides changed the same
+// fields.
+type MergeConflictError struct {
+	EntityName string
+	Fields     []string
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("quickbooks: conflicting changes to %s fields %s", e.EntityName, strings.Join(e.Fields, ", "))
+}
+
+// mergeFromServer are the fields Merge always takes from theirs, so that the
+// merged entity can be sent as an update of the server copy.
+var mergeFromServer = map[string]bool{
+	"SyncToken": true,
+	"MetaData":  true,
+}
+
+// Merge three-way merges ours and theirs, two edits of base, field by field.
+// A field changed on one side only takes that side's value, and a field both
+// sides changed to the same value keeps it. SyncToken and MetaData are taken
+// from theirs, the server copy.
+//
+// Fields are compared whole: edits to two different lines of an invoice's
+// Line both change Line and conflict. Conflicting fields are reported in a
+// *MergeConflictError.
+func Merge[T any](base, ours, theirs *T) (*T, error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot merge %s: not a struct", t)
+	}
+	if base == nil || ours == nil || theirs == nil {
+		return nil, fmt.Errorf("cannot merge %s: missing base, ours or theirs", t.Name())
+	}
+
+	merged := *theirs
+	b, o, m := reflect.ValueOf(base).Elem(), reflect.ValueOf(ours).Elem(), reflect.ValueOf(&merged).Elem()
+
+	var conflicts []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || mergeFromServer[field.Name] {
+			continue
+		}
+
+		baseField, ourField, theirField := b.Field(i).Interface(), o.Field(i).Interface(), m.Field(i).Interface()
+		switch {
+		case reflect.DeepEqual(ourField, baseField), reflect.DeepEqual(ourField, theirField):
+			// Only theirs changed, or both made the same change.
+		case reflect.DeepEqual(theirField, baseField):
+			m.Field(i).Set(o.Field(i))
+		default:
+			conflicts = append(conflicts, field.Name)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return nil, &MergeConflictError{EntityName: t.Name(), Fields: conflicts}
+	}
+
+	return &merged, nil
+}
+
+// UpdateWithMerge calls update, a strict update such as
+//
+//	func(invoice *Invoice) (*Invoice, error) {
+//		return client.UpdateInvoice(strictParams, invoice)
+//	}
+//
+// with ours, an edit of base. When it fails with a *ConflictError, ours is
+// merged with the server copy and the update retried, up to maxAttempts
+// attempts in all. Changes overlapping the server's stop the retries with a
+// *MergeConflictError.
+//
+// base and ours must be full copies of the entity: fields left out of a
+// sparse edit read as changes.
+func UpdateWithMerge[T any](base, ours *T, maxAttempts int, update func(*T) (*T, error)) (*T, error) {
+	for attempt := 1; ; attempt++ {
+		updated, err := update(ours)
+
+		var conflict *ConflictError
+		if err == nil || attempt >= maxAttempts || !errors.As(err, &conflict) {
+			return updated, err
+		}
+
+		theirs, ok := conflict.Server.(*T)
+		if !ok {
+			return nil, err
+		}
+
+		if ours, err = Merge(base, ours, theirs); err != nil {
+			return nil, err
+		}
+		base = theirs
+	}
+}
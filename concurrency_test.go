@@ -0,0 +1,111 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// itemServer serves a single item, rejecting updates with a stale SyncToken
+// like QuickBooks does.
+type itemServer struct {
+	mu    sync.Mutex
+	item  map[string]interface{}
+	reads int
+}
+
+func (s *itemServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method == http.MethodPost {
+		var update map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&update)
+		if update["SyncToken"] != s.item["SyncToken"] {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(staleObjectFault))
+			return
+		}
+		syncToken, _ := strconv.Atoi(s.item["SyncToken"].(string))
+		s.item = map[string]interface{}{
+			"Id":          s.item["Id"],
+			"SyncToken":   strconv.Itoa(syncToken + 1),
+			"Name":        update["Name"],
+			"Description": update["Description"],
+		}
+	} else {
+		s.reads++
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"Item": s.item})
+}
+
+func TestStrictUpdateReturnsConflict(t *testing.T) {
+	server := &itemServer{item: map[string]interface{}{"Id": "7", "SyncToken": "1", "Name": "Hours", "Description": "Billed weekly"}}
+	client, params := newRetryTestClient(t, nil, server.serveHTTP)
+	params.StrictConcurrency = true
+
+	attempted := &Item{Id: "7", SyncToken: "0", Name: "Hours", Description: "Billed monthly"}
+	_, err := client.UpdateItem(params, attempted)
+	require.ErrorIs(t, err, ErrStaleObject)
+
+	var conflict *ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "Item", conflict.EntityName)
+	assert.Equal(t, "7", conflict.Id)
+	assert.Same(t, attempted, conflict.Attempted)
+	require.IsType(t, &Item{}, conflict.Server)
+	assert.Equal(t, "1", conflict.Server.(*Item).SyncToken)
+	assert.Equal(t, "0", attempted.SyncToken)
+	// The item was only read to report the conflict.
+	assert.Equal(t, 1, server.reads)
+}
+
+func TestUpdateWithMerge(t *testing.T) {
+	server := &itemServer{item: map[string]interface{}{"Id": "7", "SyncToken": "1", "Name": "Hourly work", "Description": "Billed weekly"}}
+	client, params := newRetryTestClient(t, nil, server.serveHTTP)
+	params.StrictConcurrency = true
+
+	update := func(item *Item) (*Item, error) {
+		return client.UpdateItem(params, item)
+	}
+
+	base := &Item{Id: "7", SyncToken: "0", Name: "Hours", Description: "Billed weekly"}
+	ours := *base
+	ours.Description = "Billed monthly"
+
+	item, err := UpdateWithMerge(base, &ours, 3, update)
+	require.NoError(t, err)
+	assert.Equal(t, "Hourly work", item.Name)
+	assert.Equal(t, "Billed monthly", item.Description)
+	assert.Equal(t, "2", item.SyncToken)
+
+	ours = *base
+	ours.Name = "Labor"
+	_, err = UpdateWithMerge(base, &ours, 3, update)
+	var mergeConflict *MergeConflictError
+	require.ErrorAs(t, err, &mergeConflict)
+	assert.Equal(t, []string{"Name"}, mergeConflict.Fields)
+}
+
+func TestMerge(t *testing.T) {
+	base := &Customer{SyncToken: "3", DisplayName: "Bill's Windsurf Shop", Notes: "", Unknown: UnknownFields{"GSTIN": json.RawMessage(`"1"`)}}
+	ours := &Customer{SyncToken: "3", DisplayName: "Bill's Windsurf Shop", Notes: "Pays late", Unknown: UnknownFields{"GSTIN": json.RawMessage(`"1"`)}}
+	theirs := &Customer{SyncToken: "4", DisplayName: "Bill's Windsurf Shop", Notes: "Pays late", Unknown: UnknownFields{"GSTIN": json.RawMessage(`"2"`)}}
+
+	merged, err := Merge(base, ours, theirs)
+	require.NoError(t, err)
+	assert.Equal(t, "4", merged.SyncToken)
+	assert.Equal(t, "Pays late", merged.Notes)
+	assert.Equal(t, UnknownFields{"GSTIN": json.RawMessage(`"2"`)}, merged.Unknown)
+
+	ours.DisplayName = "Bill's Surf Shop"
+	theirs.DisplayName = "Bill's Windsurfing"
+	_, err = Merge(base, ours, theirs)
+	assert.EqualError(t, err, "quickbooks: conflicting changes to Customer fields DisplayName")
+}
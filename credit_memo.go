@@ -3,7 +3,6 @@ package quickbooks
 import (
 	"encoding/json"
 	"errors"
-	"strconv"
 )
 
 type CreditMemo struct {
@@ -12,7 +11,7 @@ type CreditMemo struct {
 	Line                  []Line               `json:",omitempty"`
 	ApplyTaxAfterDiscount bool                 `json:",omitempty"`
 	DocNumber             string               `json:",omitempty"`
-	TxnDate               Date                 `json:",omitempty"`
+	TxnDate               Date                 `json:",omitzero"`
 	Sparse                bool                 `json:"sparse,omitempty"`
 	CustomerMemo          MemoRef              `json:",omitempty"`
 	ProjectRef            ReferenceType        `json:",omitempty"`
@@ -24,9 +23,24 @@ type CreditMemo struct {
 	ShipAddr              PhysicalAddress      `json:",omitempty"`
 	EmailStatus           string               `json:",omitempty"`
 	BillAddr              PhysicalAddress      `json:",omitempty"`
-	MetaData              ModificationMetaData `json:",omitempty"`
+	MetaData              ModificationMetaData `json:",omitzero"`
 	BillEmail             EmailAddress         `json:",omitempty"`
 	Id                    string               `json:",omitempty"`
+
+	// Unknown holds the fields QuickBooks sent that CreditMemo does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a CreditMemo, keeping the fields it does not model in Unknown.
+func (c *CreditMemo) UnmarshalJSON(data []byte) error {
+	type creditMemo CreditMemo
+	return unmarshalWithUnknown(data, (*creditMemo)(c), &c.Unknown)
+}
+
+// MarshalJSON encodes a CreditMemo along with its Unknown fields.
+func (c CreditMemo) MarshalJSON() ([]byte, error) {
+	type creditMemo CreditMemo
+	return marshalWithUnknown(creditMemo(c), c.Unknown)
 }
 
 // CreateCreditMemo creates the given CreditMemo witin QuickBooks.
@@ -54,36 +68,7 @@ func (c *Client) DeleteCreditMemo(params RequestParameters, creditMemo *CreditMe
 
 // FindCreditMemos retrieves the full list of credit memos from QuickBooks.
 func (c *Client) FindCreditMemos(params RequestParameters) ([]CreditMemo, error) {
-	var resp struct {
-		QueryResponse struct {
-			CreditMemos   []CreditMemo `json:"CreditMemo"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM CreditMemo", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	creditMemos := make([]CreditMemo, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM CreditMemo ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		creditMemos = append(creditMemos, resp.QueryResponse.CreditMemos...)
-	}
-
-	return creditMemos, nil
+	return queryAll[CreditMemo](c, params, "")
 }
 
 // FindCreditMemoById retrieves the given credit memo from QuickBooks.
@@ -101,7 +86,7 @@ func (c *Client) FindCreditMemoById(params RequestParameters, id string) (*Credi
 }
 
 // QueryCreditMemos accepts n SQL query and returns all credit memos found using it.
-func (c *Client) QueryCreditMemos(params RequestParameters, query string) ([]CreditMemo, error) {
+func (c *Client) QueryCreditMemos(params RequestParameters, query QueryStatement) ([]CreditMemo, error) {
 	var resp struct {
 		QueryResponse struct {
 			CreditMemos   []CreditMemo `json:"CreditMemo"`
@@ -110,7 +95,7 @@ func (c *Client) QueryCreditMemos(params RequestParameters, query string) ([]Cre
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -123,29 +108,25 @@ func (c *Client) UpdateCreditMemo(params RequestParameters, creditMemo *CreditMe
 		return nil, errors.New("missing credit memo id")
 	}
 
-	existingCreditMemo, err := c.FindCreditMemoById(params, creditMemo.Id)
-	if err != nil {
-		return nil, err
-	}
-
-	creditMemo.SyncToken = existingCreditMemo.SyncToken
+	if !params.StrictConcurrency {
+		existingCreditMemo, err := c.FindCreditMemoById(params, creditMemo.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	payload := struct {
-		*CreditMemo
-		Sparse bool `json:"sparse"`
-	}{
-		CreditMemo: creditMemo,
-		Sparse:     true,
+		creditMemo.SyncToken = existingCreditMemo.SyncToken
 	}
 
+	payload := sparsePayload{creditMemo}
+
 	var creditMemoData struct {
 		CreditMemo CreditMemo
 		Time       Date
 	}
 
-	if err = c.post(params, "creditmemo", payload, &creditMemoData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "creditmemo", payload, &creditMemoData, nil); err != nil {
+		return nil, conflictError(params, creditMemo.Id, creditMemo, err, c.FindCreditMemoById)
 	}
 
-	return &creditMemoData.CreditMemo, err
+	return &creditMemoData.CreditMemo, nil
 }
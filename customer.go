@@ -8,7 +8,6 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"gopkg.in/guregu/null.v4"
 )
@@ -31,7 +30,7 @@ type Customer struct {
 	ShipAddr             *PhysicalAddress     `json:",omitempty"`
 	OpenBalanceDate      *Date                `json:",omitempty"`
 	Job                  null.Bool            `json:",omitempty"`
-	MetaData             ModificationMetaData `json:",omitempty"`
+	MetaData             ModificationMetaData `json:",omitzero"`
 	Balance              json.Number          `json:",omitempty"`
 	BalanceWithJobs      json.Number          `json:",omitempty"`
 	Id                   string               `json:",omitempty"`
@@ -61,6 +60,21 @@ type Customer struct {
 	// GSTRegistrationType
 	// GSTIN
 	// BusinessNumber
+
+	// Unknown holds the fields QuickBooks sent that Customer does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a Customer, keeping the fields it does not model in Unknown.
+func (c *Customer) UnmarshalJSON(data []byte) error {
+	type customer Customer
+	return unmarshalWithUnknown(data, (*customer)(c), &c.Unknown)
+}
+
+// MarshalJSON encodes a Customer along with its Unknown fields.
+func (c Customer) MarshalJSON() ([]byte, error) {
+	type customer Customer
+	return marshalWithUnknown(customer(c), c.Unknown)
 }
 
 // CreateCustomer creates the given Customer on the QuickBooks server,
@@ -80,36 +94,7 @@ func (c *Client) CreateCustomer(params RequestParameters, customer *Customer) (*
 
 // FindCustomers gets the full list of Customers in the QuickBooks account.
 func (c *Client) FindCustomers(params RequestParameters) ([]Customer, error) {
-	var resp struct {
-		QueryResponse struct {
-			Customers     []Customer `json:"Customer"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Customer", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	customers := make([]Customer, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Customer ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		customers = append(customers, resp.QueryResponse.Customers...)
-	}
-
-	return customers, nil
+	return queryAll[Customer](c, params, "")
 }
 
 func (c *Client) FindCustomersByPage(params RequestParameters, startPosition, pageSize int) ([]Customer, error) {
@@ -154,17 +139,21 @@ func (c *Client) FindCustomerByName(params RequestParameters, name string) (*Cus
 		}
 	}
 
-	query := "SELECT * FROM Customer WHERE DisplayName = '" + strings.Replace(name, "'", "''", -1) + "'"
+	query := "SELECT * FROM Customer WHERE DisplayName = " + quoteQueryString(name)
 
 	if err := c.query(params, query, &resp); err != nil {
 		return nil, err
 	}
 
+	if len(resp.QueryResponse.Customer) == 0 {
+		return nil, fmt.Errorf("%w: customer %q", ErrObjectNotFound, name)
+	}
+
 	return &resp.QueryResponse.Customer[0], nil
 }
 
 // QueryCustomers accepts an SQL query and returns all customers found using it
-func (c *Client) QueryCustomers(params RequestParameters, query string) ([]Customer, error) {
+func (c *Client) QueryCustomers(params RequestParameters, query QueryStatement) ([]Customer, error) {
 	var resp struct {
 		QueryResponse struct {
 			Customers     []Customer `json:"Customer"`
@@ -173,7 +162,7 @@ func (c *Client) QueryCustomers(params RequestParameters, query string) ([]Custo
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -186,12 +175,14 @@ func (c *Client) UpdateCustomer(params RequestParameters, customer *Customer) (*
 		return nil, errors.New("missing customer id")
 	}
 
-	existingCustomer, err := c.FindCustomerById(params, customer.Id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find existing customer: %v", err)
-	}
+	if !params.StrictConcurrency {
+		existingCustomer, err := c.FindCustomerById(params, customer.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find existing customer: %v", err)
+		}
 
-	customer.SyncToken = existingCustomer.SyncToken
+		customer.SyncToken = existingCustomer.SyncToken
+	}
 
 	payload := struct {
 		*Customer
@@ -204,8 +195,8 @@ func (c *Client) UpdateCustomer(params RequestParameters, customer *Customer) (*
 		Time     Date
 	}
 
-	if err = c.post(params, "customer", payload, &customerData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "customer", payload, &customerData, nil); err != nil {
+		return nil, conflictError(params, customer.Id, customer, err, c.FindCustomerById)
 	}
 
 	return &customerData.Customer, nil
@@ -217,28 +208,24 @@ func (c *Client) SparseUpdateCustomer(params RequestParameters, customer *Custom
 		return nil, errors.New("missing customer id")
 	}
 
-	existingCustomer, err := c.FindCustomerById(params, customer.Id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find existing customer: %v", err)
-	}
-
-	customer.SyncToken = existingCustomer.SyncToken
+	if !params.StrictConcurrency {
+		existingCustomer, err := c.FindCustomerById(params, customer.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find existing customer: %v", err)
+		}
 
-	payload := struct {
-		*Customer
-		Sparse bool `json:"sparse"`
-	}{
-		Customer: customer,
-		Sparse:   true,
+		customer.SyncToken = existingCustomer.SyncToken
 	}
 
+	payload := sparsePayload{customer}
+
 	var customerData struct {
 		Customer Customer
 		Time     Date
 	}
 
-	if err = c.post(params, "customer", payload, &customerData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "customer", payload, &customerData, nil); err != nil {
+		return nil, conflictError(params, customer.Id, customer, err, c.FindCustomerById)
 	}
 
 	return &customerData.Customer, nil
@@ -9,9 +9,24 @@ type CustomerType struct {
 	Name      string               `json:",omitempty"`
 	SyncToken string               `json:",omitempty"`
 	Active    bool                 `json:",omitempty"`
-	MetaData  ModificationMetaData `json:",omitempty"`
+	MetaData  ModificationMetaData `json:",omitzero"`
 	Domain    string               `json:"domain,omitempty"`
 	Status    string               `json:"status,omitempty"`
+
+	// Unknown holds the fields QuickBooks sent that CustomerType does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a CustomerType, keeping the fields it does not model in Unknown.
+func (c *CustomerType) UnmarshalJSON(data []byte) error {
+	type customerType CustomerType
+	return unmarshalWithUnknown(data, (*customerType)(c), &c.Unknown)
+}
+
+// MarshalJSON encodes a CustomerType along with its Unknown fields.
+func (c CustomerType) MarshalJSON() ([]byte, error) {
+	type customerType CustomerType
+	return marshalWithUnknown(customerType(c), c.Unknown)
 }
 
 // FindCustomerTypeById returns a customerType with a given Id.
@@ -48,7 +63,7 @@ func (c *Client) FindCustomerTypesByPage(params RequestParameters, startPosition
 }
 
 // QueryCustomerTypes accepts an SQL query and returns all customerTypes found using it
-func (c *Client) QueryCustomerTypes(params RequestParameters, query string) ([]CustomerType, error) {
+func (c *Client) QueryCustomerTypes(params RequestParameters, query QueryStatement) ([]CustomerType, error) {
 	var resp struct {
 		QueryResponse struct {
 			CustomerTypes []CustomerType `json:"CustomerType"`
@@ -57,7 +72,7 @@ func (c *Client) QueryCustomerTypes(params RequestParameters, query string) ([]C
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
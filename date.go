@@ -0,0 +1,148 @@
+package quickbooks
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	dateFormat = "2006-01-02T15:04:05-07:00"
+	dayFormat  = "2006-01-02"
+)
+
+// Date is a calendar day, such as the TxnDate or DueDate of a transaction.
+// It is time zone neutral: the embedded Time is midnight UTC of the day, and
+// the day marshals as YYYY-MM-DD. The zero Date marshals as null.
+//
+// Use DateOf and Date.StartIn to convert from and to instants in the
+// company's time zone.
+type Date struct {
+	time.Time `json:",omitempty"`
+}
+
+// DateTime is an instant, such as MetaData.LastUpdatedTime. It keeps the
+// offset QuickBooks sent it with. The zero DateTime marshals as null.
+type DateTime struct {
+	time.Time `json:",omitempty"`
+}
+
+// NewDate returns the given day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the day t falls on in loc, typically the company's time
+// zone. An invoice issued at 11pm in Los Angeles is dated that day even
+// though it is already the next day in UTC.
+func DateOf(t time.Time, loc *time.Location) Date {
+	return NewDate(t.In(loc).Date())
+}
+
+// ParseDate parses a day in YYYY-MM-DD form. Full timestamps are accepted
+// too, taking the day as written, regardless of their offset.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dayFormat, s)
+	if err != nil {
+		if t, err = time.Parse(time.RFC3339, s); err != nil {
+			return Date{}, fmt.Errorf("invalid date %q", s)
+		}
+	}
+	return NewDate(t.Date()), nil
+}
+
+// StartIn returns midnight at the start of the day in loc, typically the
+// company's time zone.
+func (d Date) StartIn(loc *time.Location) time.Time {
+	year, month, day := d.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// String returns the day as YYYY-MM-DD, as it is encoded and queried.
+func (d Date) String() string {
+	return d.Format(dayFormat)
+}
+
+// MarshalJSON encodes the day as YYYY-MM-DD, or null for the zero Date.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.Format(dayFormat) + `"`), nil
+}
+
+// UnmarshalJSON decodes a day. null and the empty string give the zero Date.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquoteTime(data)
+	if err != nil || !ok {
+		*d = Date{}
+		return err
+	}
+
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+
+	return nil
+}
+
+// ParseDateTime parses a timestamp such as 2024-05-02T10:11:12-07:00,
+// keeping its offset.
+func ParseDateTime(s string) (DateTime, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return DateTime{}, fmt.Errorf("invalid datetime %q", s)
+	}
+	return DateTime{t}, nil
+}
+
+// String returns the timestamp in the form QuickBooks uses, such as
+// 2024-05-02T10:11:12-07:00.
+func (d DateTime) String() string {
+	return d.Format(dateFormat)
+}
+
+// MarshalJSON encodes the timestamp with its offset, or null for the zero
+// DateTime.
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.Format(dateFormat) + `"`), nil
+}
+
+// UnmarshalJSON decodes a timestamp. null and the empty string give the zero
+// DateTime.
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquoteTime(data)
+	if err != nil || !ok {
+		*d = DateTime{}
+		return err
+	}
+
+	parsed, err := ParseDateTime(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+
+	return nil
+}
+
+// unquoteTime returns the string held by a JSON date or time. It reports
+// false for null and the empty string.
+func unquoteTime(data []byte) (string, bool, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return "", false, nil
+	}
+
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return "", false, fmt.Errorf("invalid date %s", data)
+	}
+
+	return s, s != "", nil
+}
@@ -0,0 +1,62 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateMarshalsDay(t *testing.T) {
+	var v struct {
+		TxnDate   Date
+		DueDate   Date
+		ShipDate  Date
+		Empty     Date
+		Timestamp DateTime
+		Missing   DateTime
+	}
+	data := []byte(`{"TxnDate":"2024-05-02","DueDate":"2024-05-02T23:30:00-07:00","ShipDate":null,"Empty":"","Timestamp":"2024-05-02T23:30:00.5-07:00","Missing":null}`)
+	require.NoError(t, json.Unmarshal(data, &v))
+
+	assert.Equal(t, NewDate(2024, time.May, 2), v.TxnDate)
+	// The day is taken as written, not as the day in UTC.
+	assert.Equal(t, NewDate(2024, time.May, 2), v.DueDate)
+	assert.Equal(t, "2024-05-02", v.DueDate.String())
+	assert.True(t, v.ShipDate.IsZero())
+	assert.True(t, v.Empty.IsZero())
+	_, offset := v.Timestamp.Zone()
+	assert.Equal(t, -7*60*60, offset)
+	assert.True(t, v.Missing.IsZero())
+
+	encoded, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"TxnDate":"2024-05-02","DueDate":"2024-05-02","ShipDate":null,"Empty":null,"Timestamp":"2024-05-02T23:30:00-07:00","Missing":null}`, string(encoded))
+
+	var invalid Date
+	assert.Error(t, json.Unmarshal([]byte(`"05/02/2024"`), &invalid))
+	assert.Error(t, json.Unmarshal([]byte(`20240502`), &invalid))
+}
+
+func TestZeroDatesAreOmitted(t *testing.T) {
+	data, err := json.Marshal(Bill{Id: "7"})
+	require.NoError(t, err)
+
+	var object map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &object))
+	assert.NotContains(t, object, "TxnDate")
+	assert.NotContains(t, object, "DueDate")
+	assert.NotContains(t, object, "MetaData")
+}
+
+func TestCompanyTimeZone(t *testing.T) {
+	losAngeles := time.FixedZone("PDT", -7*60*60)
+
+	issued := time.Date(2024, time.May, 3, 5, 30, 0, 0, time.UTC)
+	assert.Equal(t, NewDate(2024, time.May, 2), DateOf(issued, losAngeles))
+
+	start := NewDate(2024, time.May, 2).StartIn(losAngeles)
+	assert.Equal(t, time.Date(2024, time.May, 2, 7, 0, 0, 0, time.UTC), start.UTC())
+}
@@ -0,0 +1,255 @@
+package quickbooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact fixed-point decimal number, such as an amount,
+// quantity or rate. The zero value is 0.
+//
+// Decimals are immutable: arithmetic returns a new Decimal. They keep the
+// scale they were written with, so 12.50 stays 12.50.
+type Decimal struct {
+	// unscaled is the value times 10^scale. nil means 0.
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns unscaled * 10^-scale, so NewDecimal(1250, 2) is 12.50.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{unscaled: new(big.Int).Mul(big.NewInt(unscaled), pow10(-scale))}
+	}
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// maxExponent bounds the exponent ParseDecimal accepts, so that a number
+// such as "1e2000000000" cannot make it allocate gigabytes of digits.
+const maxExponent = 1000
+
+// ParseDecimal parses a decimal number such as "-12.50" or "1.5e3". The
+// exponent must be between -1000 and 1000.
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(s), "e")
+
+	whole, fraction, _ := strings.Cut(mantissa, ".")
+	digits := whole + fraction
+	if strings.TrimLeft(digits, "+-") == "" || strings.ContainsAny(fraction, "+-") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	scale := int64(len(fraction))
+	if hasExponent {
+		shift, err := strconv.ParseInt(exponent, 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		if shift < -maxExponent || shift > maxExponent {
+			return Decimal{}, fmt.Errorf("exponent of decimal %q is out of range", s)
+		}
+		scale -= shift
+	}
+	if scale < 0 {
+		return Decimal{unscaled: unscaled.Mul(unscaled, pow10(int32(-scale)))}, nil
+	}
+
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics when s is not a decimal.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromNumber converts a json.Number field to a Decimal. The empty
+// number left by an omitted field is 0.
+func DecimalFromNumber(n json.Number) (Decimal, error) {
+	if n == "" {
+		return Decimal{}, nil
+	}
+	return ParseDecimal(n.String())
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// rescale returns d's unscaled value at the given scale, which must not be
+// below d's.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Add returns d + x.
+func (d Decimal) Add(x Decimal) Decimal {
+	scale := max(d.scale, x.scale)
+	return Decimal{unscaled: new(big.Int).Add(d.rescale(scale), x.rescale(scale)), scale: scale}
+}
+
+// Sub returns d - x.
+func (d Decimal) Sub(x Decimal) Decimal {
+	scale := max(d.scale, x.scale)
+	return Decimal{unscaled: new(big.Int).Sub(d.rescale(scale), x.rescale(scale)), scale: scale}
+}
+
+// Mul returns d * x, exactly.
+func (d Decimal) Mul(x Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.int(), x.int()), scale: d.scale + x.scale}
+}
+
+// Div returns d / x rounded half away from zero to the given number of
+// decimal places. It panics when x is 0.
+func (d Decimal) Div(x Decimal, places int32) Decimal {
+	if x.Sign() == 0 {
+		panic("quickbooks: division of a Decimal by zero")
+	}
+
+	// d/x = (d.unscaled * 10^(places+1+x.scale-d.scale)) / x.unscaled at
+	// scale places+1, rounded once.
+	num, den := d.int(), x.int()
+	if shift := places + 1 + x.scale - d.scale; shift >= 0 {
+		num = new(big.Int).Mul(num, pow10(shift))
+	} else {
+		den = new(big.Int).Mul(den, pow10(-shift))
+	}
+
+	return Decimal{unscaled: new(big.Int).Quo(num, den), scale: places + 1}.Round(places)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return Decimal{unscaled: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Round returns d rounded half away from zero to the given number of decimal
+// places. Decimals with fewer places are padded with zeros, so amounts
+// print with the same number of places. Negative places round to the left of
+// the decimal point, so 1250 rounded to -2 places is 1300.
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return Decimal{unscaled: d.rescale(places), scale: places}
+	}
+
+	divisor := pow10(d.scale - places)
+	quotient, remainder := new(big.Int).QuoRem(d.int(), divisor, new(big.Int))
+
+	// Round away from zero when the remainder is at least half the divisor.
+	if remainder.Abs(remainder).Lsh(remainder, 1).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(d.Sign())))
+	}
+
+	if places < 0 {
+		return Decimal{unscaled: quotient.Mul(quotient, pow10(-places))}
+	}
+	return Decimal{unscaled: quotient, scale: places}
+}
+
+// Cmp returns -1, 0 or +1 depending on whether d is less than, equal to or
+// greater than x. Scale does not matter: 1.5 equals 1.50.
+func (d Decimal) Cmp(x Decimal) int {
+	scale := max(d.scale, x.scale)
+	return d.rescale(scale).Cmp(x.rescale(scale))
+}
+
+// Equal reports whether d and x are the same number.
+func (d Decimal) Equal(x Decimal) bool {
+	return d.Cmp(x) == 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// String returns d in plain decimal notation, such as "-12.50".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+
+	if d.scale > 0 {
+		if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(d.scale)] + "." + digits[len(digits)-int(d.scale):]
+	}
+
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Number returns d as a json.Number, for assigning to amount fields.
+func (d Decimal) Number() json.Number {
+	return json.Number(d.String())
+}
+
+// Float64 returns the float64 closest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// MarshalJSON encodes d as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number, or a string holding one. null leaves
+// d unchanged.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+
+	return nil
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,145 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for input, want := range map[string]string{
+		"12.50":  "12.50",
+		"-0.05":  "-0.05",
+		"+3":     "3",
+		".5":     "0.5",
+		"1.5e3":  "1500",
+		"25e-4":  "0.0025",
+		"-1E+2":  "-100",
+		"000.10": "0.10",
+	} {
+		d, err := ParseDecimal(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, d.String(), input)
+	}
+
+	for _, input := range []string{"", "-", ".", "1.2.3", "1.-2", "1e", "abc", " 1"} {
+		_, err := ParseDecimal(input)
+		assert.Error(t, err, input)
+	}
+
+	d, err := ParseDecimal("1e1000")
+	require.NoError(t, err)
+	assert.Len(t, d.String(), 1001)
+
+	_, err = ParseDecimal("1e2000000000")
+	assert.EqualError(t, err, `exponent of decimal "1e2000000000" is out of range`)
+	_, err = ParseDecimal("1e-1001")
+	assert.Error(t, err)
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	sum := MustParseDecimal("0.1").Add(MustParseDecimal("0.2"))
+	assert.Equal(t, "0.3", sum.String())
+	assert.True(t, sum.Equal(MustParseDecimal("0.30")))
+
+	assert.Equal(t, "-1.9", MustParseDecimal("0.1").Sub(NewDecimal(2, 0)).String())
+	assert.Equal(t, "38.2500", MustParseDecimal("3.00").Mul(MustParseDecimal("12.75")).String())
+	assert.Equal(t, "0.33", NewDecimal(1, 0).Div(NewDecimal(3, 0), 2).String())
+	assert.Equal(t, "-0.67", NewDecimal(-2, 0).Div(NewDecimal(3, 0), 2).String())
+	assert.Equal(t, "120", NewDecimal(12, 0).Div(MustParseDecimal("0.1"), 0).String())
+	assert.Panics(t, func() { NewDecimal(1, 0).Div(Decimal{}, 2) })
+
+	assert.Equal(t, "2.68", MustParseDecimal("2.675").Round(2).String())
+	assert.Equal(t, "-2.68", MustParseDecimal("-2.675").Round(2).String())
+	assert.Equal(t, "2.67", MustParseDecimal("2.6749").Round(2).String())
+	assert.Equal(t, "-0.01", MustParseDecimal("-0.005").Round(2).String())
+	assert.Equal(t, "5.00", NewDecimal(5, 0).Round(2).String())
+	assert.Equal(t, "1300", NewDecimal(1250, 0).Round(-2).String())
+	assert.Equal(t, "-1000", MustParseDecimal("-1499.99").Round(-3).String())
+	assert.Equal(t, "0", MustParseDecimal("4.9").Round(-1).String())
+
+	assert.Equal(t, -1, MustParseDecimal("1.5").Cmp(MustParseDecimal("1.51")))
+	assert.Equal(t, 0, MustParseDecimal("1.5").Cmp(MustParseDecimal("1.500")))
+	assert.True(t, Decimal{}.IsZero())
+	assert.Equal(t, "0", Decimal{}.String())
+	assert.Equal(t, "1.25", NewDecimal(-125, 2).Abs().String())
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Amount    Decimal
+		Quoted    Decimal
+		Null      Decimal
+		Rate      Decimal
+		Untouched Decimal
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"Amount":1234.50,"Quoted":"-0.75","Null":null,"Rate":1e-3}`), &v))
+	assert.Equal(t, "1234.50", v.Amount.String())
+	assert.Equal(t, "-0.75", v.Quoted.String())
+	assert.True(t, v.Null.IsZero())
+	assert.Equal(t, "0.001", v.Rate.String())
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Amount":1234.50,"Quoted":-0.75,"Null":0,"Rate":0.001,"Untouched":0}`, string(data))
+}
+
+func TestMoney(t *testing.T) {
+	price := NewMoney(MustParseDecimal("19.99"), "usd")
+	total := price.Mul(MustParseDecimal("0.15"))
+	assert.Equal(t, "3.00 USD", total.String())
+
+	assert.Equal(t, "1235 JPY", NewMoney(MustParseDecimal("1234.5"), "JPY").String())
+	assert.Equal(t, "1.235 KWD", NewMoney(MustParseDecimal("1.2345"), "KWD").String())
+
+	sum, err := price.Add(total)
+	require.NoError(t, err)
+	assert.Equal(t, "22.99 USD", sum.String())
+
+	_, err = price.Add(NewMoney(MustParseDecimal("1"), "EUR"))
+	assert.EqualError(t, err, "currency mismatch: USD and EUR")
+}
+
+func TestDecimalAccessors(t *testing.T) {
+	invoice := Invoice{
+		TotalAmt: "0.30",
+		Line: []Line{
+			mustLine(NewSalesItemLine(ReferenceType{Value: "1"}, "1", "0.1", "")),
+			mustLine(NewSalesItemLine(ReferenceType{Value: "2"}, "1", "0.2", "")),
+			NewDescriptionLine("Thank you"),
+		},
+	}
+
+	var sum Decimal
+	for _, line := range invoice.Line {
+		amount, err := line.AmountDecimal()
+		require.NoError(t, err)
+		sum = sum.Add(amount)
+	}
+
+	total, err := invoice.TotalAmtDecimal()
+	require.NoError(t, err)
+	assert.True(t, sum.Equal(total))
+
+	invoice.Balance = sum.Number()
+	assert.Equal(t, json.Number("0.30"), invoice.Balance)
+}
+
+func TestMoneyAccessors(t *testing.T) {
+	invoice := Invoice{TotalAmt: "1234.5", CurrencyRef: ReferenceType{Value: "JPY"}}
+	total, err := invoice.TotalAmtMoney()
+	require.NoError(t, err)
+	assert.Equal(t, "1235 JPY", total.String())
+
+	customer := Customer{Balance: "12.5"}
+	balance, err := customer.BalanceMoney()
+	require.NoError(t, err)
+	assert.Equal(t, "", balance.Currency)
+
+	customer.CurrencyRef = &ReferenceType{Value: "EUR"}
+	balance, err = customer.BalanceMoney()
+	require.NoError(t, err)
+	assert.Equal(t, "12.50 EUR", balance.String())
+}
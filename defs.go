@@ -3,10 +3,7 @@
 
 package quickbooks
 
-import (
-	"encoding/json"
-	"time"
-)
+import "encoding/json"
 
 type CustomField struct {
 	DefinitionId string `json:"DefinitionId,omitempty"`
@@ -15,44 +12,12 @@ type CustomField struct {
 	Name         string `json:"Name,omitempty"`
 }
 
-// Date represents a Quickbooks date
-type Date struct {
-	time.Time `json:",omitempty"`
-}
-
-// DateTime represents a Quickbooks datatime
-type DateTime struct {
-	time.Time `json:",omitempty"`
-}
-
-// UnmarshalJSON removes time from parsed date
-func (d *Date) UnmarshalJSON(b []byte) (err error) {
-	if b[0] == '"' && b[len(b)-1] == '"' {
-		b = b[1 : len(b)-1]
-	}
-
-	d.Time, err = time.Parse(dateFormat, string(b))
-	if err != nil {
-		d.Time, err = time.Parse(dayFormat, string(b))
-	}
-
-	return err
-}
-
-func (d Date) String() string {
-	return d.Format(dateFormat)
-}
-
 // EmailAddress represents a QuickBooks email address.
 type EmailAddress struct {
 	Address string `json:",omitempty"`
 }
 
-const (
-	QueryPageSize = 1000
-	dateFormat    = "2006-01-02T15:04:05-07:00"
-	dayFormat     = "2006-01-02"
-)
+const QueryPageSize = 1000
 
 // MemoRef represents a QuickBooks MemoRef object.
 type MemoRef struct {
@@ -61,8 +26,8 @@ type MemoRef struct {
 
 // ModificationMetaData is a timestamp of genesis and last change of a Quickbooks object
 type ModificationMetaData struct {
-	CreateTime      Date `json:",omitempty"`
-	LastUpdatedTime Date `json:",omitempty"`
+	CreateTime      DateTime `json:",omitzero"`
+	LastUpdatedTime DateTime `json:",omitzero"`
 }
 
 // PhysicalAddress represents a QuickBooks address.
@@ -108,7 +73,7 @@ type MarkupInfo struct {
 
 type DeliveryInfo struct {
 	DeliveryType string
-	DeliveryTime Date
+	DeliveryTime DateTime `json:",omitzero"`
 }
 
 type ContactInfo struct {
@@ -141,8 +106,11 @@ const (
 	TaxLine            LineDetailTypeEnum = "TaxLineDetail"
 	ReimburseLine      LineDetailTypeEnum = "ReimburseLineDetail"
 	DepositLine        LineDetailTypeEnum = "DepositLineDetail"
+	JournalEntryLine   LineDetailTypeEnum = "JournalEntryLineDetail"
 )
 
+// Line is a line of a transaction. Only the detail matching DetailType is
+// sent to QuickBooks; see MarshalJSON.
 type Line struct {
 	Id                            string                        `json:",omitempty"`
 	LineNum                       int                           `json:",omitempty"`
@@ -160,7 +128,13 @@ type Line struct {
 	SubTotalLineDetail            SubTotalLineDetail            `json:",omitempty"`
 	TaxLineDetail                 TaxLineDetail                 `json:",omitempty"`
 	ReimburseLineDetail           ReimburseLineDetail           `json:",omitempty"`
-	DepositLineDetail
+	JournalEntryLineDetail        JournalEntryLineDetail        `json:",omitempty"`
+	// DepositLineDetail is embedded so that its fields stay promoted, as
+	// line.AccountRef, while the tag keeps it nested in JSON.
+	DepositLineDetail `json:"DepositLineDetail,omitempty"`
+	// RawDetail holds the detail of a DetailType this package does not
+	// model, as QuickBooks sent it.
+	RawDetail json.RawMessage `json:"-"`
 }
 
 type BillableStatusEnum string
@@ -206,7 +180,7 @@ type SalesItemLineDetail struct {
 	Qty             json.Number   `json:",omitempty"`
 	ItemAccountRef  ReferenceType `json:",omitempty"`
 	TaxCodeRef      ReferenceType `json:",omitempty"`
-	ServiceDate     Date          `json:",omitempty"`
+	ServiceDate     Date          `json:",omitzero"`
 	TaxInclusiveAmt json.Number   `json:",omitempty"`
 	DiscountRate    json.Number   `json:",omitempty"`
 	DiscountAmt     json.Number   `json:",omitempty"`
@@ -222,7 +196,7 @@ type GroupLineDetail struct {
 // DescriptionLineDetail ...
 type DescriptionLineDetail struct {
 	TaxCodeRef  ReferenceType `json:",omitempty"`
-	ServiceDate Date          `json:",omitempty"`
+	ServiceDate Date          `json:",omitzero"`
 }
 
 // DiscountLineDetail ...
@@ -272,6 +246,34 @@ type DepositLineDetail struct {
 	// TxnType
 }
 
+// PostingTypeEnum is the side of a journal entry line.
+type PostingTypeEnum string
+
+const (
+	DebitPostingType  PostingTypeEnum = "Debit"
+	CreditPostingType PostingTypeEnum = "Credit"
+)
+
+// JournalEntryEntity is the customer, vendor or employee a journal entry
+// line is for. Type is "Customer", "Vendor" or "Employee".
+type JournalEntryEntity struct {
+	Type      string
+	EntityRef ReferenceType
+}
+
+// JournalEntryLineDetail ...
+type JournalEntryLineDetail struct {
+	PostingType     PostingTypeEnum
+	AccountRef      ReferenceType
+	Entity          *JournalEntryEntity `json:",omitempty"`
+	ClassRef        *ReferenceType      `json:",omitempty"`
+	DepartmentRef   *ReferenceType      `json:",omitempty"`
+	TaxCodeRef      *ReferenceType      `json:",omitempty"`
+	TaxApplicableOn string              `json:",omitempty"`
+	TaxAmount       json.Number         `json:",omitempty"`
+	BillableStatus  BillableStatusEnum  `json:",omitempty"`
+}
+
 type TaxTypeEnum string
 
 const (
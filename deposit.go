@@ -14,7 +14,7 @@ type Deposit struct {
 	DepartmentRef       *ReferenceType       `json:",omitempty"`
 	RecurDataRef        *ReferenceType       `json:",omitempty"`
 	TxnDate             *Date                `json:",omitempty"`
-	MetaData            ModificationMetaData `json:",omitempty"`
+	MetaData            ModificationMetaData `json:",omitzero"`
 	ExchangeRate        json.Number          `json:",omitempty"`
 	TotalAmt            json.Number          `json:",omitempty"`
 	HomeTotalAmt        json.Number          `json:",omitempty"`
@@ -26,6 +26,21 @@ type Deposit struct {
 	// GlobalTaxCalculation
 	// CashBackInfo
 	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that Deposit does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a Deposit, keeping the fields it does not model in Unknown.
+func (d *Deposit) UnmarshalJSON(data []byte) error {
+	type deposit Deposit
+	return unmarshalWithUnknown(data, (*deposit)(d), &d.Unknown)
+}
+
+// MarshalJSON encodes a Deposit along with its Unknown fields.
+func (d Deposit) MarshalJSON() ([]byte, error) {
+	type deposit Deposit
+	return marshalWithUnknown(deposit(d), d.Unknown)
 }
 
 // CreateDeposit creates the given deposit within QuickBooks
@@ -52,36 +67,7 @@ func (c *Client) DeleteDeposit(params RequestParameters, deposit *Deposit) error
 
 // FindDeposits gets the full list of Deposits in the QuickBooks account.
 func (c *Client) FindDeposits(params RequestParameters) ([]Deposit, error) {
-	var resp struct {
-		QueryResponse struct {
-			Deposits      []Deposit `json:"Deposit"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Deposit", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	deposits := make([]Deposit, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Deposit ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		deposits = append(deposits, resp.QueryResponse.Deposits...)
-	}
-
-	return deposits, nil
+	return queryAll[Deposit](c, params, "")
 }
 
 func (c *Client) FindDepositsByPage(params RequestParameters, startPosition, pageSize int) ([]Deposit, error) {
@@ -118,7 +104,7 @@ func (c *Client) FindDepositById(params RequestParameters, id string) (*Deposit,
 }
 
 // QueryDeposits accepts an SQL query and returns all deposits found using it
-func (c *Client) QueryDeposits(params RequestParameters, query string) ([]Deposit, error) {
+func (c *Client) QueryDeposits(params RequestParameters, query QueryStatement) ([]Deposit, error) {
 	var resp struct {
 		QueryResponse struct {
 			Deposits      []Deposit `json:"Deposit"`
@@ -127,7 +113,7 @@ func (c *Client) QueryDeposits(params RequestParameters, query string) ([]Deposi
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -140,12 +126,14 @@ func (c *Client) UpdateDeposit(params RequestParameters, deposit *Deposit) (*Dep
 		return nil, errors.New("missing deposit id")
 	}
 
-	existingDeposit, err := c.FindDepositById(params, deposit.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingDeposit, err := c.FindDepositById(params, deposit.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	deposit.SyncToken = existingDeposit.SyncToken
+		deposit.SyncToken = existingDeposit.SyncToken
+	}
 
 	payload := struct {
 		*Deposit
@@ -158,11 +146,11 @@ func (c *Client) UpdateDeposit(params RequestParameters, deposit *Deposit) (*Dep
 		Time    Date
 	}
 
-	if err = c.post(params, "deposit", payload, &depositData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "deposit", payload, &depositData, nil); err != nil {
+		return nil, conflictError(params, deposit.Id, deposit, err, c.FindDepositById)
 	}
 
-	return &depositData.Deposit, err
+	return &depositData.Deposit, nil
 }
 
 // SparseUpdateDeposit updates only fields included in the deposit struct, other fields are left unmodified
@@ -171,29 +159,25 @@ func (c *Client) SparseUpdateDeposit(params RequestParameters, deposit *Deposit)
 		return nil, errors.New("missing deposit id")
 	}
 
-	existingDeposit, err := c.FindDepositById(params, deposit.Id)
-	if err != nil {
-		return nil, err
-	}
-
-	deposit.SyncToken = existingDeposit.SyncToken
+	if !params.StrictConcurrency {
+		existingDeposit, err := c.FindDepositById(params, deposit.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	payload := struct {
-		*Deposit
-		Sparse bool `json:"sparse"`
-	}{
-		Deposit: deposit,
-		Sparse:  true,
+		deposit.SyncToken = existingDeposit.SyncToken
 	}
 
+	payload := sparsePayload{deposit}
+
 	var depositData struct {
 		Deposit Deposit
 		Time    Date
 	}
 
-	if err = c.post(params, "deposit", payload, &depositData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "deposit", payload, &depositData, nil); err != nil {
+		return nil, conflictError(params, deposit.Id, deposit, err, c.FindDepositById)
 	}
 
-	return &depositData.Deposit, err
+	return &depositData.Deposit, nil
 }
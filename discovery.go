@@ -1,12 +1,111 @@
 package quickbooks
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Environment selects the QuickBooks environment a Client talks to.
+type Environment string
+
+const (
+	Sandbox    Environment = "sandbox"
+	Production Environment = "production"
 )
 
+const (
+	SandboxEndpoint    = "https://sandbox-quickbooks.api.intuit.com"
+	ProductionEndpoint = "https://quickbooks.api.intuit.com"
+
+	SandboxDiscoveryEndpoint    = "https://developer.api.intuit.com/.well-known/openid_sandbox_configuration"
+	ProductionDiscoveryEndpoint = "https://developer.api.intuit.com/.well-known/openid_configuration"
+)
+
+// DefaultDiscoveryTTL is how long a discovery document is cached when the
+// response carries no Cache-Control max-age.
+const DefaultDiscoveryTTL = 24 * time.Hour
+
+// MinDiscoveryTTL is the shortest time a discovery document or key set is
+// cached, even when the response asks not to be cached at all.
+const MinDiscoveryTTL = 5 * time.Minute
+
+// Endpoint returns the base URL of the accounting API in the environment.
+func (e Environment) Endpoint() string {
+	switch e {
+	case Sandbox:
+		return SandboxEndpoint
+	case Production:
+		return ProductionEndpoint
+	}
+	return ""
+}
+
+// DiscoveryEndpoint returns the URL of the environment's OpenID discovery
+// document.
+func (e Environment) DiscoveryEndpoint() string {
+	switch e {
+	case Sandbox:
+		return SandboxDiscoveryEndpoint
+	case Production:
+		return ProductionDiscoveryEndpoint
+	}
+	return ""
+}
+
+// other returns the environment e must not be mixed with.
+func (e Environment) other() Environment {
+	if e == Sandbox {
+		return Production
+	}
+	return Sandbox
+}
+
+// userinfoHosts are the hosts of each environment's userinfo endpoint, the
+// one endpoint that tells the discovery documents apart.
+var userinfoHosts = map[Environment]string{
+	Sandbox:    "sandbox-accounts.platform.intuit.com",
+	Production: "accounts.platform.intuit.com",
+}
+
+// checkEnvironment reports a client configuration mixing environments.
+func checkEnvironment(env Environment, endpoint string, discovery *DiscoveryAPI) error {
+	if env.Endpoint() == "" {
+		return fmt.Errorf("unknown environment %q", env)
+	}
+
+	if endpoint != "" && sameHost(endpoint, env.other().Endpoint()) {
+		return fmt.Errorf("%s endpoint %s cannot be used in the %s environment", env.other(), endpoint, env)
+	}
+
+	if discovery != nil && discovery.UserinfoEndpoint != "" && sameHost(discovery.UserinfoEndpoint, "https://"+userinfoHosts[env.other()]) {
+		return fmt.Errorf("%s discovery document cannot be used in the %s environment", env.other(), env)
+	}
+
+	return nil
+}
+
+func sameHost(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(ua.Hostname(), ub.Hostname())
+}
+
 type DiscoveryAPI struct {
 	Issuer                string `json:"issuer"`
 	AuthorizationEndpoint string `json:"authorization_endpoint"`
@@ -18,31 +117,128 @@ type DiscoveryAPI struct {
 
 // CallDiscoveryAPI
 // See https://developer.intuit.com/app/developer/qbo/docs/develop/authentication-and-authorization/openid-connect#discovery-document
+//
+// Deprecated: Set ClientRequest.Environment and let the Client fetch and
+// cache the document, or use FetchDiscoveryAPI.
 func CallDiscoveryAPI(discoveryEndpoint string) (*DiscoveryAPI, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", string(discoveryEndpoint), nil)
+	return FetchDiscoveryAPI(context.Background(), http.DefaultClient, discoveryEndpoint)
+}
+
+// FetchDiscoveryAPI fetches the discovery document at discoveryEndpoint
+// with the given HTTP client.
+func FetchDiscoveryAPI(ctx context.Context, client *http.Client, discoveryEndpoint string) (*DiscoveryAPI, error) {
+	discovery, _, err := fetchDiscoveryAPI(ctx, client, discoveryEndpoint)
+	return discovery, err
+}
+
+// fetchDiscoveryAPI fetches a discovery document, returning how long it may
+// be cached.
+func fetchDiscoveryAPI(ctx context.Context, client *http.Client, discoveryEndpoint string) (*DiscoveryAPI, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", discoveryEndpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create req: %v", err)
+		return nil, 0, fmt.Errorf("failed to create req: %v", err)
 	}
 
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make req: %v", err)
+		return nil, 0, fmt.Errorf("failed to make req: %w", err)
 	}
 
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read body: %v", err)
+		return nil, 0, fmt.Errorf("failed to read body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)}
 	}
 
 	respData := DiscoveryAPI{}
 	if err = json.Unmarshal(body, &respData); err != nil {
-		return nil, fmt.Errorf("error getting DiscoveryAPIResponse: %v", err)
+		return nil, 0, fmt.Errorf("error getting DiscoveryAPIResponse: %v", err)
+	}
+
+	return &respData, cacheTTL(resp.Header.Get("Cache-Control")), nil
+}
+
+// cacheTTL returns how long a response may be cached according to its
+// Cache-Control header, but no less than MinDiscoveryTTL.
+func cacheTTL(cacheControl string) time.Duration {
+	ttl := DefaultDiscoveryTTL
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache":
+			return MinDiscoveryTTL
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil && seconds >= 0 {
+				ttl = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return max(ttl, MinDiscoveryTTL)
+}
+
+// discoveryCache holds a Client's discovery document.
+type discoveryCache struct {
+	endpoint string
+	group    singleflight.Group
+
+	mu        sync.Mutex
+	discovery *DiscoveryAPI
+	expires   time.Time
+	// static documents were passed in ClientRequest and never expire.
+	static bool
+}
+
+// DiscoveryAPI returns the discovery document of the client's environment,
+// fetching it when the cached copy has expired. Concurrent callers share a
+// single fetch. A stale copy is returned if fetching fails.
+func (c *Client) DiscoveryAPI(ctx context.Context) (*DiscoveryAPI, error) {
+	cache := c.discovery
+
+	cache.mu.Lock()
+	stale := cache.discovery
+	fresh := stale != nil && (cache.static || time.Now().Before(cache.expires))
+	cache.mu.Unlock()
+
+	if fresh {
+		return stale, nil
 	}
+	if cache.endpoint == "" {
+		return nil, fmt.Errorf("missing discovery document: set ClientRequest.Environment or ClientRequest.DiscoveryAPI")
+	}
+
+	// The fetch is shared by every waiting caller, so it must not be cut
+	// short when the caller that started it gives up.
+	ch := cache.group.DoChan("", func() (interface{}, error) {
+		discovery, ttl, err := fetchDiscoveryAPI(context.WithoutCancel(ctx), c.Client, cache.endpoint)
+		if err != nil {
+			return nil, err
+		}
 
-	return &respData, nil
+		cache.mu.Lock()
+		cache.discovery = discovery
+		cache.expires = time.Now().Add(ttl)
+		cache.mu.Unlock()
+
+		return discovery, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			if stale != nil {
+				return stale, nil
+			}
+			return nil, fmt.Errorf("failed to fetch discovery document: %w", res.Err)
+		}
+		return res.Val.(*DiscoveryAPI), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
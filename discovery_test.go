@@ -0,0 +1,100 @@
+package quickbooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// discoveryTestClient answers discovery requests with the given Cache-Control
+// header, counting them.
+func discoveryTestClient(cacheControl string, fetches *atomic.Int32) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		fetches.Add(1)
+		header := http.Header{"Cache-Control": {cacheControl}}
+		body := `{"issuer":"https://oauth.platform.intuit.com/op/v1","token_endpoint":"` + r.URL.String() + `/token"}`
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(body)), Request: r}, nil
+	})}
+}
+
+func TestEnvironmentChoosesEndpoints(t *testing.T) {
+	var fetches atomic.Int32
+	client, err := NewClient(ClientRequest{
+		Client:      discoveryTestClient("max-age=3600", &fetches),
+		Environment: Sandbox,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, SandboxEndpoint+"/v3/company/", client.baseEndpoint.String())
+
+	discovery, err := client.DiscoveryAPI(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, SandboxDiscoveryEndpoint+"/token", discovery.TokenEndpoint)
+
+	_, err = client.DiscoveryAPI(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fetches.Load())
+
+	client.discovery.expires = time.Now().Add(-time.Second)
+	_, err = client.DiscoveryAPI(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestDiscoveryCachesForMinTTL(t *testing.T) {
+	var fetches atomic.Int32
+	client, err := NewClient(ClientRequest{
+		Client:      discoveryTestClient("no-cache", &fetches),
+		Environment: Production,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.DiscoveryAPI(context.Background())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), fetches.Load())
+
+	assert.Equal(t, MinDiscoveryTTL, cacheTTL("no-cache"))
+	assert.Equal(t, MinDiscoveryTTL, cacheTTL("max-age=10"))
+	assert.Equal(t, 90*time.Minute, cacheTTL("public, max-age=5400"))
+	assert.Equal(t, DefaultDiscoveryTTL, cacheTTL(""))
+}
+
+func TestEnvironmentMismatch(t *testing.T) {
+	_, err := NewClient(ClientRequest{Environment: Sandbox, Endpoint: ProductionEndpoint})
+	assert.EqualError(t, err, "production endpoint https://quickbooks.api.intuit.com cannot be used in the sandbox environment")
+
+	_, err = NewClient(ClientRequest{
+		Environment:  Production,
+		DiscoveryAPI: &DiscoveryAPI{UserinfoEndpoint: "https://sandbox-accounts.platform.intuit.com/v1/openid_connect/userinfo"},
+	})
+	assert.EqualError(t, err, "sandbox discovery document cannot be used in the production environment")
+
+	_, err = NewClient(ClientRequest{Environment: "staging"})
+	assert.EqualError(t, err, `unknown environment "staging"`)
+
+	client, err := NewClient(ClientRequest{Endpoint: "https://example.com"})
+	require.NoError(t, err)
+	_, err = client.DiscoveryAPI(context.Background())
+	assert.Error(t, err)
+}
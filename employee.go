@@ -14,7 +14,7 @@ type Employee struct {
 	BirthDate        *Date                `json:",omitempty"`
 	HiredDate        *Date                `json:",omitempty"`
 	ReleasedDate     *Date                `json:",omitempty"`
-	MetaData         ModificationMetaData `json:",omitempty"`
+	MetaData         ModificationMetaData `json:",omitzero"`
 	CostRate         json.Number          `json:",omitempty"`
 	BillRate         json.Number          `json:",omitempty"`
 	Id               string               `json:",omitempty"`
@@ -34,6 +34,21 @@ type Employee struct {
 	Organization     bool                 `json:",omitempty"`
 	Domain           string               `json:"domain,omitempty"`
 	Status           string               `json:"status,omitempty"`
+
+	// Unknown holds the fields QuickBooks sent that Employee does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes an Employee, keeping the fields it does not model in Unknown.
+func (e *Employee) UnmarshalJSON(data []byte) error {
+	type employee Employee
+	return unmarshalWithUnknown(data, (*employee)(e), &e.Unknown)
+}
+
+// MarshalJSON encodes an Employee along with its Unknown fields.
+func (e Employee) MarshalJSON() ([]byte, error) {
+	type employee Employee
+	return marshalWithUnknown(employee(e), e.Unknown)
 }
 
 // CreateEmployee creates the given employee within QuickBooks
@@ -52,36 +67,7 @@ func (c *Client) CreateEmployee(params RequestParameters, employee *Employee) (*
 
 // FindEmployees gets the full list of Employees in the QuickBooks account.
 func (c *Client) FindEmployees(params RequestParameters) ([]Employee, error) {
-	var resp struct {
-		QueryResponse struct {
-			Employees     []Employee `json:"Employee"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Employee", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	employees := make([]Employee, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Employee ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		employees = append(employees, resp.QueryResponse.Employees...)
-	}
-
-	return employees, nil
+	return queryAll[Employee](c, params, "")
 }
 
 // FindEmployeeById returns an employee with a given Id.
@@ -118,7 +104,7 @@ func (c *Client) FindEmployeesByPage(params RequestParameters, startPosition, pa
 }
 
 // QueryEmployees accepts an SQL query and returns all employees found using it
-func (c *Client) QueryEmployees(params RequestParameters, query string) ([]Employee, error) {
+func (c *Client) QueryEmployees(params RequestParameters, query QueryStatement) ([]Employee, error) {
 	var resp struct {
 		QueryResponse struct {
 			Employees     []Employee `json:"Employee"`
@@ -127,7 +113,7 @@ func (c *Client) QueryEmployees(params RequestParameters, query string) ([]Emplo
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -140,29 +126,25 @@ func (c *Client) UpdateEmployee(params RequestParameters, employee *Employee) (*
 		return nil, errors.New("missing employee id")
 	}
 
-	existingEmployee, err := c.FindEmployeeById(params, employee.Id)
-	if err != nil {
-		return nil, err
-	}
-
-	employee.SyncToken = existingEmployee.SyncToken
+	if !params.StrictConcurrency {
+		existingEmployee, err := c.FindEmployeeById(params, employee.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	payload := struct {
-		*Employee
-		Sparse bool `json:"sparse"`
-	}{
-		Employee: employee,
-		Sparse:   true,
+		employee.SyncToken = existingEmployee.SyncToken
 	}
 
+	payload := sparsePayload{employee}
+
 	var employeeData struct {
 		Employee Employee
 		Time     Date
 	}
 
-	if err = c.post(params, "employee", payload, &employeeData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "employee", payload, &employeeData, nil); err != nil {
+		return nil, conflictError(params, employee.Id, employee, err, c.FindEmployeeById)
 	}
 
-	return &employeeData.Employee, err
+	return &employeeData.Employee, nil
 }
@@ -0,0 +1,72 @@
+package quickbooks
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Entity is the set of QuickBooks objects modeled by this package.
+type Entity interface {
+	Account | Attachable | Bill | BillPayment | Class | CreditMemo | Customer |
+		CustomerType | Deposit | Employee | Estimate | Invoice | Item |
+		JournalEntry | Payment | PaymentMethod | Purchase | PurchaseOrder |
+		RefundReceipt | ReimburseCharge | SalesReceipt | TaxCode | TaxRate |
+		Term | TimeActivity | Transfer | Vendor | VendorCredit
+}
+
+// entityTypes is the shared registry mapping QuickBooks entity names, as
+// used in queries, batch and change data capture payloads, to their types.
+var entityTypes = map[string]reflect.Type{
+	"Account":         reflect.TypeFor[Account](),
+	"Attachable":      reflect.TypeFor[Attachable](),
+	"Bill":            reflect.TypeFor[Bill](),
+	"BillPayment":     reflect.TypeFor[BillPayment](),
+	"Class":           reflect.TypeFor[Class](),
+	"CreditMemo":      reflect.TypeFor[CreditMemo](),
+	"Customer":        reflect.TypeFor[Customer](),
+	"CustomerType":    reflect.TypeFor[CustomerType](),
+	"Deposit":         reflect.TypeFor[Deposit](),
+	"Employee":        reflect.TypeFor[Employee](),
+	"Estimate":        reflect.TypeFor[Estimate](),
+	"Invoice":         reflect.TypeFor[Invoice](),
+	"Item":            reflect.TypeFor[Item](),
+	"JournalEntry":    reflect.TypeFor[JournalEntry](),
+	"Payment":         reflect.TypeFor[Payment](),
+	"PaymentMethod":   reflect.TypeFor[PaymentMethod](),
+	"Purchase":        reflect.TypeFor[Purchase](),
+	"PurchaseOrder":   reflect.TypeFor[PurchaseOrder](),
+	"RefundReceipt":   reflect.TypeFor[RefundReceipt](),
+	"ReimburseCharge": reflect.TypeFor[ReimburseCharge](),
+	"SalesReceipt":    reflect.TypeFor[SalesReceipt](),
+	"TaxCode":         reflect.TypeFor[TaxCode](),
+	"TaxRate":         reflect.TypeFor[TaxRate](),
+	"Term":            reflect.TypeFor[Term](),
+	"TimeActivity":    reflect.TypeFor[TimeActivity](),
+	"Transfer":        reflect.TypeFor[Transfer](),
+	"Vendor":          reflect.TypeFor[Vendor](),
+	"VendorCredit":    reflect.TypeFor[VendorCredit](),
+}
+
+// entityNames is the reverse of entityTypes.
+var entityNames = func() map[reflect.Type]string {
+	names := make(map[reflect.Type]string, len(entityTypes))
+	for name, t := range entityTypes {
+		names[t] = name
+	}
+	return names
+}()
+
+// EntityNames returns the names of every entity modeled by this package.
+func EntityNames() []string {
+	names := make([]string, 0, len(entityTypes))
+	for name := range entityTypes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// EntityName returns the QuickBooks name of the entity type T.
+func EntityName[T Entity]() string {
+	return entityNames[reflect.TypeFor[T]()]
+}
@@ -4,50 +4,190 @@
 package quickbooks
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
+	"strings"
 )
 
+// FaultCode is the code QuickBooks attaches to every error of a fault.
+type FaultCode string
+
+const (
+	FaultCodeGeneralAuthentication FaultCode = "100"
+	FaultCodeObjectNotFound        FaultCode = "610"
+	FaultCodeAuthenticationFailed  FaultCode = "3200"
+	FaultCodeStaleObject           FaultCode = "5010"
+	FaultCodeDuplicateName         FaultCode = "6240"
+)
+
+// Sentinel errors matched by errors.Is against a Failure, a BatchError or an
+// HTTPError carrying the corresponding fault code or status.
+var (
+	ErrUnauthorized   = errors.New("quickbooks: authentication failed")
+	ErrObjectNotFound = errors.New("quickbooks: object not found")
+	ErrStaleObject    = errors.New("quickbooks: stale object")
+	ErrDuplicateName  = errors.New("quickbooks: duplicate name exists")
+)
+
+// Err returns the sentinel error matching the code, or nil if there is none.
+func (c FaultCode) Err() error {
+	switch c {
+	case FaultCodeGeneralAuthentication, FaultCodeAuthenticationFailed:
+		return ErrUnauthorized
+	case FaultCodeObjectNotFound:
+		return ErrObjectNotFound
+	case FaultCodeStaleObject:
+		return ErrStaleObject
+	case FaultCodeDuplicateName:
+		return ErrDuplicateName
+	}
+	return nil
+}
+
 // Failure is the outermost struct that holds an error response.
 type Failure struct {
 	Fault struct {
 		Error []struct {
 			Message string
 			Detail  string
-			Code    string `json:"code"`
-			Element string `json:"element"`
+			Code    FaultCode `json:"code"`
+			Element string    `json:"element"`
 		}
 		Type string `json:"type"`
 	}
-	Time Date `json:"time"`
+	Time DateTime `json:"time"`
+	// StatusCode is the HTTP status of the response carrying the fault.
+	StatusCode int `json:"-"`
+	// IntuitTID is the intuit_tid response header, which Intuit support asks
+	// for when investigating a request.
+	IntuitTID string `json:"-"`
 }
 
 // Error implements the error interface.
 func (f Failure) Error() string {
-	text, err := json.Marshal(f)
-	if err != nil {
-		return fmt.Sprintf("unexpected error while marshalling error: %v", err)
+	faultType := f.Fault.Type
+	if faultType == "" {
+		faultType = "Fault"
+	}
+
+	msgs := make([]string, len(f.Fault.Error))
+	for i, e := range f.Fault.Error {
+		msg := fmt.Sprintf("%s (code %s)", e.Message, e.Code)
+		if e.Detail != "" && e.Detail != e.Message {
+			msg += ": " + e.Detail
+		}
+		if e.Element != "" {
+			msg += " [element " + e.Element + "]"
+		}
+		msgs[i] = msg
+	}
+
+	text := "quickbooks " + faultType + ": " + strings.Join(msgs, "; ")
+	if f.StatusCode != 0 {
+		text += fmt.Sprintf(" (status %d", f.StatusCode)
+		if f.IntuitTID != "" {
+			text += ", intuit_tid " + f.IntuitTID
+		}
+		text += ")"
+	}
+
+	return text
+}
+
+// Is reports whether any error of the fault maps to target.
+func (f Failure) Is(target error) bool {
+	for _, e := range f.Fault.Error {
+		if err := e.Code.Err(); err != nil && err == target {
+			return true
+		}
+	}
+	return f.StatusCode == http.StatusUnauthorized && target == ErrUnauthorized
+}
+
+// HasCode reports whether the fault contains an error with the given code.
+func (f Failure) HasCode(code FaultCode) bool {
+	for _, e := range f.Fault.Error {
+		if e.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPError is returned for unsuccessful responses whose body is not a
+// QuickBooks fault, such as the HTML pages served with a 503.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       string
+	IntuitTID  string
+}
+
+// Error implements the error interface.
+func (e HTTPError) Error() string {
+	body := strings.TrimSpace(e.Body)
+	if len(body) > 200 {
+		body = body[:200] + "..."
+	}
+
+	text := "quickbooks: unexpected HTTP status " + e.Status
+	if e.IntuitTID != "" {
+		text += " (intuit_tid " + e.IntuitTID + ")"
 	}
+	if body != "" {
+		text += ": " + body
+	}
+
+	return text
+}
 
-	return string(text)
+// Is maps a 401 status to ErrUnauthorized.
+func (e HTTPError) Is(target error) bool {
+	return e.StatusCode == http.StatusUnauthorized && target == ErrUnauthorized
 }
 
 // parseFailure takes a response reader and tries to parse a Failure.
 func parseFailure(resp *http.Response) error {
-	msg, err := io.ReadAll(resp.Body)
+	var reader io.Reader = resp.Body
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to create gzip reader: %v", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	msg, err := io.ReadAll(reader)
 	if err != nil {
 		return errors.New("When reading response body:" + err.Error())
 	}
 
+	return failureFromBody(resp, msg)
+}
+
+// failureFromBody builds a Failure from an already read response body,
+// falling back to an HTTPError when the body holds no fault.
+func failureFromBody(resp *http.Response, body []byte) error {
+	intuitTID := resp.Header.Get("intuit_tid")
+
 	var errStruct Failure
 
-	if err = json.Unmarshal(msg, &errStruct); err != nil {
-		return errors.New(strconv.Itoa(resp.StatusCode) + " " + string(msg))
+	if err := json.Unmarshal(body, &errStruct); err != nil || len(errStruct.Fault.Error) == 0 {
+		return HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       string(body),
+			IntuitTID:  intuitTID,
+		}
 	}
 
+	errStruct.StatusCode = resp.StatusCode
+	errStruct.IntuitTID = intuitTID
+
 	return errStruct
 }
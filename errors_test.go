@@ -0,0 +1,68 @@
+package quickbooks
+
+import (
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const staleObjectFault = `{"Fault":{"Error":[{"Message":"Stale Object Error","Detail":"Stale Object Error : You and admin were working on this at the same time.","code":"5010","element":""}],"type":"ValidationFault"},"time":"2024-05-02T10:11:12.345-07:00"}`
+
+func TestFailureMatchesSentinels(t *testing.T) {
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("intuit_tid", "1-abc-2")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusBadRequest)
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(staleObjectFault))
+		gz.Close()
+	})
+
+	_, err := client.ChangeDataCapture(params, []string{"Invoice"}, time.Now())
+	require.ErrorIs(t, err, ErrStaleObject)
+	assert.NotErrorIs(t, err, ErrDuplicateName)
+
+	var failure Failure
+	require.ErrorAs(t, err, &failure)
+	assert.Equal(t, http.StatusBadRequest, failure.StatusCode)
+	assert.Equal(t, "1-abc-2", failure.IntuitTID)
+	assert.True(t, failure.HasCode(FaultCodeStaleObject))
+	assert.Equal(t, "quickbooks ValidationFault: Stale Object Error (code 5010): Stale Object Error : You and admin were working on this at the same time. (status 400, intuit_tid 1-abc-2)", failure.Error())
+}
+
+func TestNonJSONFailureIsHTTPError(t *testing.T) {
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("<html><body>Service Unavailable</body></html>"))
+	})
+
+	_, err := client.FindItemById(params, "1")
+
+	var httpErr HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	assert.Contains(t, httpErr.Body, "Service Unavailable")
+	assert.False(t, errors.As(err, &Failure{}))
+}
+
+func TestUnauthorizedStatus(t *testing.T) {
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"fault":{"error":[{"message":"message=AuthenticationFailed; errorCode=003200; statusCode=401","detail":"Token expired","code":"3200"}],"type":"AUTHENTICATION"}}`))
+	})
+
+	_, err := client.FindCompanyInfo(params)
+	require.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestBatchErrorMatchesSentinels(t *testing.T) {
+	err := error(BatchError{Faults: []BatchFault{{Message: "Duplicate Name Exists Error", Code: FaultCodeDuplicateName}}})
+	assert.ErrorIs(t, err, ErrDuplicateName)
+	assert.NotErrorIs(t, err, ErrStaleObject)
+}
@@ -33,7 +33,7 @@ type Estimate struct {
 	ExpirationDate        *Date                `json:",omitempty"`
 	DueDate               *Date                `json:",omitempty"`
 	CustomerMemo          MemoRef              `json:",omitempty"`
-	MetaData              ModificationMetaData `json:",omitempty"`
+	MetaData              ModificationMetaData `json:",omitzero"`
 	ExchangeRate          json.Number          `json:",omitempty"`
 	TotalAmt              json.Number          `json:",omitempty"`
 	HomeTotalAmt          json.Number          `json:",omitempty"`
@@ -51,6 +51,21 @@ type Estimate struct {
 	Status                string               `json:"status,omitempty"`
 	// GlobalTaxCalculation
 	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that Estimate does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes an Estimate, keeping the fields it does not model in Unknown.
+func (e *Estimate) UnmarshalJSON(data []byte) error {
+	type estimate Estimate
+	return unmarshalWithUnknown(data, (*estimate)(e), &e.Unknown)
+}
+
+// MarshalJSON encodes an Estimate along with its Unknown fields.
+func (e Estimate) MarshalJSON() ([]byte, error) {
+	type estimate Estimate
+	return marshalWithUnknown(estimate(e), e.Unknown)
 }
 
 // CreateEstimate creates the given Estimate on the QuickBooks server, returning
@@ -79,36 +94,7 @@ func (c *Client) DeleteEstimate(params RequestParameters, estimate *Estimate) er
 
 // FindEstimates gets the full list of Estimates in the QuickBooks account.
 func (c *Client) FindEstimates(params RequestParameters) ([]Estimate, error) {
-	var resp struct {
-		QueryResponse struct {
-			Estimates     []Estimate `json:"Estimate"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Estimate", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	estimates := make([]Estimate, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Estimate ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		estimates = append(estimates, resp.QueryResponse.Estimates...)
-	}
-
-	return estimates, nil
+	return queryAll[Estimate](c, params, "")
 }
 
 func (c *Client) FindEstimatesByPage(params RequestParameters, startPosition, pageSize int) ([]Estimate, error) {
@@ -145,7 +131,7 @@ func (c *Client) FindEstimateById(params RequestParameters, id string) (*Estimat
 }
 
 // QueryEstimates accepts an SQL query and returns all estimates found using it
-func (c *Client) QueryEstimates(params RequestParameters, query string) ([]Estimate, error) {
+func (c *Client) QueryEstimates(params RequestParameters, query QueryStatement) ([]Estimate, error) {
 	var resp struct {
 		QueryResponse struct {
 			Estimates     []Estimate `json:"Estimate"`
@@ -154,7 +140,7 @@ func (c *Client) QueryEstimates(params RequestParameters, query string) ([]Estim
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -178,12 +164,14 @@ func (c *Client) UpdateEstimate(params RequestParameters, estimate *Estimate) (*
 		return nil, errors.New("missing estimate id")
 	}
 
-	existingEstimate, err := c.FindEstimateById(params, estimate.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingEstimate, err := c.FindEstimateById(params, estimate.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	estimate.SyncToken = existingEstimate.SyncToken
+		estimate.SyncToken = existingEstimate.SyncToken
+	}
 
 	payload := struct {
 		*Estimate
@@ -196,11 +184,11 @@ func (c *Client) UpdateEstimate(params RequestParameters, estimate *Estimate) (*
 		Time     Date
 	}
 
-	if err = c.post(params, "estimate", payload, &estimateData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "estimate", payload, &estimateData, nil); err != nil {
+		return nil, conflictError(params, estimate.Id, estimate, err, c.FindEstimateById)
 	}
 
-	return &estimateData.Estimate, err
+	return &estimateData.Estimate, nil
 }
 
 // SparseUpdateEstimate updates only fields included in the estimate struct, other fields are left unmodified
@@ -209,31 +197,27 @@ func (c *Client) SparseUpdateEstimate(params RequestParameters, estimate *Estima
 		return nil, errors.New("missing estimate id")
 	}
 
-	existingEstimate, err := c.FindEstimateById(params, estimate.Id)
-	if err != nil {
-		return nil, err
-	}
-
-	estimate.SyncToken = existingEstimate.SyncToken
+	if !params.StrictConcurrency {
+		existingEstimate, err := c.FindEstimateById(params, estimate.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	payload := struct {
-		*Estimate
-		Sparse bool `json:"sparse"`
-	}{
-		Estimate: estimate,
-		Sparse:   true,
+		estimate.SyncToken = existingEstimate.SyncToken
 	}
 
+	payload := sparsePayload{estimate}
+
 	var estimateData struct {
 		Estimate Estimate
 		Time     Date
 	}
 
-	if err = c.post(params, "estimate", payload, &estimateData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "estimate", payload, &estimateData, nil); err != nil {
+		return nil, conflictError(params, estimate.Id, estimate, err, c.FindEstimateById)
 	}
 
-	return &estimateData.Estimate, err
+	return &estimateData.Estimate, nil
 }
 
 func (c *Client) VoidEstimate(params RequestParameters, estimate Estimate) error {
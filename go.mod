@@ -5,12 +5,13 @@ go 1.24.0
 require (
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/oauth2 v0.19.0
+	golang.org/x/sync v0.10.0
+	golang.org/x/time v0.10.0
 	gopkg.in/guregu/null.v4 v4.0.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/time v0.10.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
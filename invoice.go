@@ -36,7 +36,7 @@ type Invoice struct {
 	ShipDate                     *Date                `json:",omitempty"`
 	DueDate                      *Date                `json:",omitempty"`
 	CustomerMemo                 MemoRef              `json:",omitempty"`
-	MetaData                     ModificationMetaData `json:",omitempty"`
+	MetaData                     ModificationMetaData `json:",omitzero"`
 	ExchangeRate                 json.Number          `json:",omitempty"`
 	Deposit                      json.Number          `json:",omitempty"`
 	TotalAmt                     json.Number          `json:",omitempty"`
@@ -60,6 +60,21 @@ type Invoice struct {
 	// InvoiceLink                  string               `json:",omitempty"`
 	// GlobalTaxCalculation
 	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that Invoice does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes an Invoice, keeping the fields it does not model in Unknown.
+func (i *Invoice) UnmarshalJSON(data []byte) error {
+	type invoice Invoice
+	return unmarshalWithUnknown(data, (*invoice)(i), &i.Unknown)
+}
+
+// MarshalJSON encodes an Invoice along with its Unknown fields.
+func (i Invoice) MarshalJSON() ([]byte, error) {
+	type invoice Invoice
+	return marshalWithUnknown(invoice(i), i.Unknown)
 }
 
 // CreateInvoice creates the given Invoice on the QuickBooks server, returning
@@ -96,36 +111,7 @@ func (c *Client) DeleteInvoice(params RequestParameters, invoice *Invoice) error
 
 // FindInvoices gets the full list of Invoices in the QuickBooks account.
 func (c *Client) FindInvoices(params RequestParameters) ([]Invoice, error) {
-	var resp struct {
-		QueryResponse struct {
-			Invoices      []Invoice `json:"Invoice"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Invoice", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	invoices := make([]Invoice, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Invoice ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		invoices = append(invoices, resp.QueryResponse.Invoices...)
-	}
-
-	return invoices, nil
+	return queryAll[Invoice](c, params, "")
 }
 
 func (c *Client) FindInvoicesByPage(params RequestParameters, startPosition, pageSize int) ([]Invoice, error) {
@@ -162,7 +148,7 @@ func (c *Client) FindInvoiceById(params RequestParameters, id string) (*Invoice,
 }
 
 // QueryInvoices accepts an SQL query and returns all invoices found using it
-func (c *Client) QueryInvoices(params RequestParameters, query string) ([]Invoice, error) {
+func (c *Client) QueryInvoices(params RequestParameters, query QueryStatement) ([]Invoice, error) {
 	var resp struct {
 		QueryResponse struct {
 			Invoices      []Invoice `json:"Invoice"`
@@ -171,7 +157,7 @@ func (c *Client) QueryInvoices(params RequestParameters, query string) ([]Invoic
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -195,12 +181,14 @@ func (c *Client) UpdateInvoice(params RequestParameters, invoice *Invoice) (*Inv
 		return nil, errors.New("missing invoice id")
 	}
 
-	existingInvoice, err := c.FindInvoiceById(params, invoice.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingInvoice, err := c.FindInvoiceById(params, invoice.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	invoice.SyncToken = existingInvoice.SyncToken
+		invoice.SyncToken = existingInvoice.SyncToken
+	}
 
 	payload := struct {
 		*Invoice
@@ -213,11 +201,11 @@ func (c *Client) UpdateInvoice(params RequestParameters, invoice *Invoice) (*Inv
 		Time    Date
 	}
 
-	if err = c.post(params, "invoice", payload, &invoiceData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "invoice", payload, &invoiceData, nil); err != nil {
+		return nil, conflictError(params, invoice.Id, invoice, err, c.FindInvoiceById)
 	}
 
-	return &invoiceData.Invoice, err
+	return &invoiceData.Invoice, nil
 }
 
 // SparseUpdateInvoice updates only fields included in the invoice struct, other fields are left unmodified
@@ -226,31 +214,27 @@ func (c *Client) SparseUpdateInvoice(params RequestParameters, invoice *Invoice)
 		return nil, errors.New("missing invoice id")
 	}
 
-	existingInvoice, err := c.FindInvoiceById(params, invoice.Id)
-	if err != nil {
-		return nil, err
-	}
-
-	invoice.SyncToken = existingInvoice.SyncToken
+	if !params.StrictConcurrency {
+		existingInvoice, err := c.FindInvoiceById(params, invoice.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	payload := struct {
-		*Invoice
-		Sparse bool `json:"sparse"`
-	}{
-		Invoice: invoice,
-		Sparse:  true,
+		invoice.SyncToken = existingInvoice.SyncToken
 	}
 
+	payload := sparsePayload{invoice}
+
 	var invoiceData struct {
 		Invoice Invoice
 		Time    Date
 	}
 
-	if err = c.post(params, "invoice", payload, &invoiceData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "invoice", payload, &invoiceData, nil); err != nil {
+		return nil, conflictError(params, invoice.Id, invoice, err, c.FindInvoiceById)
 	}
 
-	return &invoiceData.Invoice, err
+	return &invoiceData.Invoice, nil
 }
 
 func (c *Client) VoidInvoice(params RequestParameters, invoice Invoice) error {
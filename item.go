@@ -20,8 +20,8 @@ type Item struct {
 	ClassRef             *ReferenceType       `json:",omitempty"`
 	PrefVendorRef        *ReferenceType       `json:",omitempty"`
 	ParentRef            *ReferenceType       `json:",omitempty"`
-	InvStartDate         Date                 `json:",omitempty"`
-	MetaData             ModificationMetaData `json:",omitempty"`
+	InvStartDate         Date                 `json:",omitzero"`
+	MetaData             ModificationMetaData `json:",omitzero"`
 	QtyOnHand            json.Number          `json:",omitempty"`
 	ReorderPoint         json.Number          `json:",omitempty"`
 	PurchaseCost         json.Number          `json:",omitempty"`
@@ -49,6 +49,21 @@ type Item struct {
 	// UQCId
 	// ReverseChargeRate
 	// ServiceType
+
+	// Unknown holds the fields QuickBooks sent that Item does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes an Item, keeping the fields it does not model in Unknown.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	type item Item
+	return unmarshalWithUnknown(data, (*item)(i), &i.Unknown)
+}
+
+// MarshalJSON encodes an Item along with its Unknown fields.
+func (i Item) MarshalJSON() ([]byte, error) {
+	type item Item
+	return marshalWithUnknown(item(i), i.Unknown)
 }
 
 func (c *Client) CreateItem(params RequestParameters, item *Item) (*Item, error) {
@@ -66,36 +81,7 @@ func (c *Client) CreateItem(params RequestParameters, item *Item) (*Item, error)
 
 // FindItems gets the full list of Items in the QuickBooks account.
 func (c *Client) FindItems(params RequestParameters) ([]Item, error) {
-	var resp struct {
-		QueryResponse struct {
-			Items         []Item `json:"Item"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Item", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	items := make([]Item, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Item ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		items = append(items, resp.QueryResponse.Items...)
-	}
-
-	return items, nil
+	return queryAll[Item](c, params, "")
 }
 
 func (c *Client) FindItemsByPage(params RequestParameters, startPosition, pageSize int) ([]Item, error) {
@@ -132,7 +118,7 @@ func (c *Client) FindItemById(params RequestParameters, id string) (*Item, error
 }
 
 // QueryItems accepts an SQL query and returns all items found using it
-func (c *Client) QueryItems(params RequestParameters, query string) ([]Item, error) {
+func (c *Client) QueryItems(params RequestParameters, query QueryStatement) ([]Item, error) {
 	var resp struct {
 		QueryResponse struct {
 			Items         []Item `json:"Item"`
@@ -141,7 +127,7 @@ func (c *Client) QueryItems(params RequestParameters, query string) ([]Item, err
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -154,12 +140,14 @@ func (c *Client) UpdateItem(params RequestParameters, item *Item) (*Item, error)
 		return nil, errors.New("missing item id")
 	}
 
-	existingItem, err := c.FindItemById(params, item.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingItem, err := c.FindItemById(params, item.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	item.SyncToken = existingItem.SyncToken
+		item.SyncToken = existingItem.SyncToken
+	}
 
 	payload := struct {
 		*Item
@@ -172,9 +160,9 @@ func (c *Client) UpdateItem(params RequestParameters, item *Item) (*Item, error)
 		Time Date
 	}
 
-	if err = c.post(params, "item", payload, &itemData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "item", payload, &itemData, nil); err != nil {
+		return nil, conflictError(params, item.Id, item, err, c.FindItemById)
 	}
 
-	return &itemData.Item, err
+	return &itemData.Item, nil
 }
@@ -0,0 +1,350 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JournalEntry represents a QuickBooks JournalEntry object. Its lines are
+// JournalEntryLine lines, whose debits must equal their credits.
+type JournalEntry struct {
+	Line                 []Line               `json:",omitempty"`
+	TxnTaxDetail         *TxnTaxDetail        `json:",omitempty"`
+	RecurDataRef         *ReferenceType       `json:",omitempty"`
+	CurrencyRef          ReferenceType        `json:",omitempty"`
+	TxnDate              *Date                `json:",omitempty"`
+	MetaData             ModificationMetaData `json:",omitzero"`
+	ExchangeRate         json.Number          `json:",omitempty"`
+	TotalAmt             json.Number          `json:",omitempty"`
+	HomeTotalAmt         json.Number          `json:",omitempty"`
+	Id                   string               `json:",omitempty"`
+	DocNumber            string               `json:",omitempty"`
+	SyncToken            string               `json:",omitempty"`
+	PrivateNote          string               `json:",omitempty"`
+	TxnSource            string               `json:",omitempty"`
+	GlobalTaxCalculation string               `json:",omitempty"`
+	Adjustment           bool                 `json:",omitempty"`
+	Domain               string               `json:"domain,omitempty"`
+	Status               string               `json:"status,omitempty"`
+	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that JournalEntry does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a JournalEntry, keeping the fields it does not model in Unknown.
+func (j *JournalEntry) UnmarshalJSON(data []byte) error {
+	type journalEntry JournalEntry
+	return unmarshalWithUnknown(data, (*journalEntry)(j), &j.Unknown)
+}
+
+// MarshalJSON encodes a JournalEntry along with its Unknown fields.
+func (j JournalEntry) MarshalJSON() ([]byte, error) {
+	type journalEntry JournalEntry
+	return marshalWithUnknown(journalEntry(j), j.Unknown)
+}
+
+// Validate checks that the entry has JournalEntryLine lines, each with a
+// PostingType, and that debits equal credits, both in the entry's currency
+// and, when it has an ExchangeRate, in the home currency. homeCurrency is the
+// ISO 4217 code of the company's home currency, whose decimal places the
+// home totals are rounded to; it is not used without an ExchangeRate.
+func (j *JournalEntry) Validate(homeCurrency string) error {
+	rate, err := DecimalFromNumber(j.ExchangeRate)
+	if err != nil {
+		return fmt.Errorf("invalid exchange rate: %v", err)
+	}
+
+	var lines int
+	var debits, credits, homeDebits, homeCredits Decimal
+	for i, line := range j.Line {
+		if line.DetailType != JournalEntryLine {
+			continue
+		}
+		lines++
+
+		amount, err := line.AmountDecimal()
+		if err != nil {
+			return fmt.Errorf("journal entry line %d: %v", i+1, err)
+		}
+		homeAmount := amount.Mul(rate)
+
+		switch line.JournalEntryLineDetail.PostingType {
+		case DebitPostingType:
+			debits, homeDebits = debits.Add(amount), homeDebits.Add(homeAmount)
+		case CreditPostingType:
+			credits, homeCredits = credits.Add(amount), homeCredits.Add(homeAmount)
+		default:
+			return fmt.Errorf("journal entry line %d has no PostingType", i+1)
+		}
+	}
+	if lines == 0 {
+		return errors.New("journal entry has no JournalEntryLine lines")
+	}
+
+	if !debits.Equal(credits) {
+		return fmt.Errorf("journal entry does not balance: debits %s, credits %s", debits, credits)
+	}
+	if rate.IsZero() {
+		return nil
+	}
+
+	// Only the totals are rounded, as rounding every line would let a
+	// balanced entry fail on rounding alone.
+	places := CurrencyDecimals(homeCurrency)
+	homeDebits, homeCredits = homeDebits.Round(places), homeCredits.Round(places)
+	if !homeDebits.Equal(homeCredits) {
+		return fmt.Errorf("journal entry does not balance in home currency: debits %s, credits %s", homeDebits, homeCredits)
+	}
+
+	return nil
+}
+
+// validateJournalEntry validates the entry as validateJournalEntries does.
+func (c *Client) validateJournalEntry(params RequestParameters, journalEntry *JournalEntry) error {
+	_, err := c.validateJournalEntries(params, []*JournalEntry{journalEntry})
+	return err
+}
+
+// validateJournalEntries validates the entries, then checks that their lines
+// posting to Accounts Receivable carry a customer and those posting to
+// Accounts Payable a vendor, which QuickBooks requires. The home currency
+// and the accounts of every entry are each looked up once. On failure it
+// returns the index of the entry at fault.
+func (c *Client) validateJournalEntries(params RequestParameters, journalEntries []*JournalEntry) (int, error) {
+	var homeCurrency string
+	for _, journalEntry := range journalEntries {
+		if journalEntry.ExchangeRate != "" {
+			var err error
+			if homeCurrency, err = c.homeCurrency(params); err != nil {
+				return 0, err
+			}
+			break
+		}
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for i, journalEntry := range journalEntries {
+		if err := journalEntry.Validate(homeCurrency); err != nil {
+			return i, err
+		}
+
+		for _, line := range journalEntry.Line {
+			id := line.JournalEntryLineDetail.AccountRef.Value
+			if line.DetailType == JournalEntryLine && id != "" && !seen[id] {
+				seen[id] = true
+				ids = append(ids, quoteQueryString(id))
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// A batch can post to more accounts than fit in one page of results.
+	accounts, err := queryAll[Account](c, params, "SELECT * FROM Account WHERE Id IN ("+strings.Join(ids, ", ")+")")
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up journal entry accounts: %w", err)
+	}
+
+	accountTypes := make(map[string]AccountTypeEnum, len(accounts))
+	for _, account := range accounts {
+		accountTypes[account.Id] = account.AccountType
+	}
+
+	for i, journalEntry := range journalEntries {
+		if err := checkJournalEntryEntities(journalEntry, accountTypes); err != nil {
+			return i, err
+		}
+	}
+
+	return 0, nil
+}
+
+// checkJournalEntryEntities checks the entities of the entry's lines against
+// the types of the accounts they post to.
+func checkJournalEntryEntities(journalEntry *JournalEntry, accountTypes map[string]AccountTypeEnum) error {
+	for i, line := range journalEntry.Line {
+		if line.DetailType != JournalEntryLine {
+			continue
+		}
+
+		detail := line.JournalEntryLineDetail
+
+		var want string
+		switch accountTypes[detail.AccountRef.Value] {
+		case AccountsReceivableAccountType:
+			want = "Customer"
+		case AccountsPayableAccountType:
+			want = "Vendor"
+		default:
+			continue
+		}
+
+		if detail.Entity == nil || detail.Entity.Type != want || detail.Entity.EntityRef.Value == "" {
+			return fmt.Errorf("journal entry line %d posts to %s account %s without a %s entity", i+1, accountTypes[detail.AccountRef.Value], detail.AccountRef.Value, strings.ToLower(want))
+		}
+	}
+
+	return nil
+}
+
+// CreateJournalEntry validates the given JournalEntry and creates it on the
+// QuickBooks server, returning the resulting JournalEntry object.
+func (c *Client) CreateJournalEntry(params RequestParameters, journalEntry *JournalEntry) (*JournalEntry, error) {
+	if err := c.validateJournalEntry(params, journalEntry); err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		JournalEntry JournalEntry
+		Time         Date
+	}
+
+	if err := c.post(params, "journalentry", journalEntry, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return &resp.JournalEntry, nil
+}
+
+// DeleteJournalEntry deletes the journal entry.
+func (c *Client) DeleteJournalEntry(params RequestParameters, journalEntry *JournalEntry) error {
+	if journalEntry.Id == "" || journalEntry.SyncToken == "" {
+		return errors.New("missing id/sync token")
+	}
+
+	return c.post(params, "journalentry", journalEntry, nil, map[string]string{"operation": "delete"})
+}
+
+// FindJournalEntries gets the full list of JournalEntries in the QuickBooks account.
+func (c *Client) FindJournalEntries(params RequestParameters) ([]JournalEntry, error) {
+	return queryAll[JournalEntry](c, params, "")
+}
+
+func (c *Client) FindJournalEntriesByPage(params RequestParameters, startPosition, pageSize int) ([]JournalEntry, error) {
+	var resp struct {
+		QueryResponse struct {
+			JournalEntries []JournalEntry `json:"JournalEntry"`
+			MaxResults     int
+			StartPosition  int
+			TotalCount     int
+		}
+	}
+
+	query := "SELECT * FROM JournalEntry ORDERBY Id STARTPOSITION " + strconv.Itoa(startPosition) + " MAXRESULTS " + strconv.Itoa(pageSize)
+
+	if err := c.query(params, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QueryResponse.JournalEntries, nil
+}
+
+// FindJournalEntryById finds the journal entry by the given id
+func (c *Client) FindJournalEntryById(params RequestParameters, id string) (*JournalEntry, error) {
+	var resp struct {
+		JournalEntry JournalEntry
+		Time         Date
+	}
+
+	if err := c.get(params, "journalentry/"+id, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return &resp.JournalEntry, nil
+}
+
+// QueryJournalEntries accepts an SQL query and returns all journal entries found using it
+func (c *Client) QueryJournalEntries(params RequestParameters, query QueryStatement) ([]JournalEntry, error) {
+	var resp struct {
+		QueryResponse struct {
+			JournalEntries []JournalEntry `json:"JournalEntry"`
+			StartPosition  int
+			MaxResults     int
+		}
+	}
+
+	if err := c.queryStatement(params, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QueryResponse.JournalEntries, nil
+}
+
+// UpdateJournalEntry validates the journal entry and full updates it, meaning that missing writable fields will be set to nil/null
+func (c *Client) UpdateJournalEntry(params RequestParameters, journalEntry *JournalEntry) (*JournalEntry, error) {
+	if journalEntry.Id == "" {
+		return nil, errors.New("missing journal entry id")
+	}
+
+	if err := c.validateJournalEntry(params, journalEntry); err != nil {
+		return nil, err
+	}
+
+	if !params.StrictConcurrency {
+		existingJournalEntry, err := c.FindJournalEntryById(params, journalEntry.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		journalEntry.SyncToken = existingJournalEntry.SyncToken
+	}
+
+	payload := struct {
+		*JournalEntry
+	}{
+		JournalEntry: journalEntry,
+	}
+
+	var journalEntryData struct {
+		JournalEntry JournalEntry
+		Time         Date
+	}
+
+	if err := c.post(params, "journalentry", payload, &journalEntryData, nil); err != nil {
+		return nil, conflictError(params, journalEntry.Id, journalEntry, err, c.FindJournalEntryById)
+	}
+
+	return &journalEntryData.JournalEntry, nil
+}
+
+// SparseUpdateJournalEntry updates only fields included in the journal entry struct, other fields are left unmodified.
+// Lines, when included, are validated as in UpdateJournalEntry.
+func (c *Client) SparseUpdateJournalEntry(params RequestParameters, journalEntry *JournalEntry) (*JournalEntry, error) {
+	if journalEntry.Id == "" {
+		return nil, errors.New("missing journal entry id")
+	}
+
+	if len(journalEntry.Line) > 0 {
+		if err := c.validateJournalEntry(params, journalEntry); err != nil {
+			return nil, err
+		}
+	}
+
+	if !params.StrictConcurrency {
+		existingJournalEntry, err := c.FindJournalEntryById(params, journalEntry.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		journalEntry.SyncToken = existingJournalEntry.SyncToken
+	}
+
+	payload := sparsePayload{journalEntry}
+
+	var journalEntryData struct {
+		JournalEntry JournalEntry
+		Time         Date
+	}
+
+	if err := c.post(params, "journalentry", payload, &journalEntryData, nil); err != nil {
+		return nil, conflictError(params, journalEntry.Id, journalEntry, err, c.FindJournalEntryById)
+	}
+
+	return &journalEntryData.JournalEntry, nil
+}
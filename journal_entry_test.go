@@ -0,0 +1,106 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalEntryValidate(t *testing.T) {
+	cash, revenue := ReferenceType{Value: "35"}, ReferenceType{Value: "79"}
+
+	entry := JournalEntry{Line: []Line{
+		NewJournalEntryLine(DebitPostingType, cash, "100.00"),
+		NewJournalEntryLine(CreditPostingType, revenue, "60"),
+		NewJournalEntryLine(CreditPostingType, revenue, "40.00"),
+		NewDescriptionLine("accrual"),
+	}}
+	assert.NoError(t, entry.Validate(""))
+
+	entry.Line[2].Amount = "39.99"
+	assert.EqualError(t, entry.Validate(""), "journal entry does not balance: debits 100.00, credits 99.99")
+
+	entry.Line[2].JournalEntryLineDetail.PostingType = ""
+	assert.EqualError(t, entry.Validate(""), "journal entry line 3 has no PostingType")
+
+	foreign := JournalEntry{ExchangeRate: "1.01", Line: []Line{
+		NewJournalEntryLine(DebitPostingType, cash, "0.50"),
+		NewJournalEntryLine(CreditPostingType, revenue, "0.25"),
+		NewJournalEntryLine(CreditPostingType, revenue, "0.25"),
+	}}
+	assert.NoError(t, foreign.Validate("USD"))
+
+	foreign = JournalEntry{ExchangeRate: "1.5", Line: []Line{
+		NewJournalEntryLine(DebitPostingType, cash, "0.01"),
+		NewJournalEntryLine(DebitPostingType, cash, "0.01"),
+		NewJournalEntryLine(CreditPostingType, revenue, "0.02"),
+	}}
+	assert.NoError(t, foreign.Validate("USD"))
+
+	foreign = JournalEntry{ExchangeRate: "150.2", Line: []Line{
+		NewJournalEntryLine(DebitPostingType, cash, "10.004"),
+		NewJournalEntryLine(CreditPostingType, revenue, "10.000"),
+		NewJournalEntryLine(CreditPostingType, revenue, "0.004"),
+	}}
+	assert.NoError(t, foreign.Validate("JPY"))
+
+	empty := JournalEntry{Line: []Line{NewDescriptionLine("accrual")}}
+	assert.EqualError(t, empty.Validate(""), "journal entry has no JournalEntryLine lines")
+}
+
+func TestCreateJournalEntryChecksEntities(t *testing.T) {
+	var query string
+	var queries int
+	var created bool
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/company/1234/preferences":
+			w.Write([]byte(`{"Preferences":{"CurrencyPrefs":{"HomeCurrency":{"value":"USD"}}}}`))
+		case "/v3/company/1234/query":
+			queries++
+			query = r.URL.Query().Get("query")
+			w.Write([]byte(`{"QueryResponse":{"Account":[{"Id":"84","AccountType":"Accounts Receivable"},{"Id":"79","AccountType":"Income"}]}}`))
+		case "/v3/company/1234/journalentry":
+			created = true
+			var body JournalEntry
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "6", body.Line[0].JournalEntryLineDetail.Entity.EntityRef.Value)
+			w.Write([]byte(`{"JournalEntry":{"Id":"9"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	entry := &JournalEntry{Line: []Line{
+		NewJournalEntryLine(DebitPostingType, ReferenceType{Value: "84"}, "25"),
+		NewJournalEntryLine(CreditPostingType, ReferenceType{Value: "79"}, "25"),
+	}}
+
+	_, err := client.CreateJournalEntry(params, entry)
+	assert.EqualError(t, err, "journal entry line 1 posts to Accounts Receivable account 84 without a customer entity")
+	assert.Equal(t, "SELECT * FROM Account WHERE Id IN ('84', '79') ORDERBY Id STARTPOSITION 1 MAXRESULTS 1000", query)
+	assert.False(t, created)
+
+	// The accounts of the whole batch are looked up at once.
+	queries = 0
+	income := &JournalEntry{ExchangeRate: "1.1", Line: []Line{
+		NewJournalEntryLine(DebitPostingType, ReferenceType{Value: "35"}, "25"),
+		NewJournalEntryLine(CreditPostingType, ReferenceType{Value: "79"}, "25"),
+	}}
+	_, err = client.ExecuteBatch(params, []BatchItemRequest{BatchCreate("1", income), BatchCreate("2", entry)})
+	assert.EqualError(t, err, "batch item 2: journal entry line 1 posts to Accounts Receivable account 84 without a customer entity")
+	assert.Equal(t, 1, queries)
+	assert.Equal(t, "SELECT * FROM Account WHERE Id IN ('35', '79', '84') ORDERBY Id STARTPOSITION 1 MAXRESULTS 1000", query)
+
+	_, err = client.ExecuteBatch(params, []BatchItemRequest{BatchCreate("1", income), BatchCreate("2", &JournalEntry{})})
+	assert.EqualError(t, err, "batch item 2: journal entry has no JournalEntryLine lines")
+
+	entry.Line[0].JournalEntryLineDetail.Entity = &JournalEntryEntity{Type: "Customer", EntityRef: ReferenceType{Value: "6"}}
+	journalEntry, err := client.CreateJournalEntry(params, entry)
+	require.NoError(t, err)
+	assert.Equal(t, "9", journalEntry.Id)
+	assert.True(t, created)
+}
@@ -0,0 +1,190 @@
+package quickbooks
+
+import (
+	"encoding/json"
+)
+
+// lineDetailKeys maps the modeled detail types to the field holding their
+// detail, which is also its JSON key.
+var lineDetailKeys = map[LineDetailTypeEnum]string{
+	SalesItemLine:      "SalesItemLineDetail",
+	GroupLine:          "GroupLineDetail",
+	DescriptionLine:    "DescriptionLineDetail",
+	DiscountLine:       "DiscountLineDetail",
+	SubTotalLine:       "SubTotalLineDetail",
+	ItemExpenseLine:    "ItemBasedExpenseLineDetail",
+	AccountExpenseLine: "AccountBasedExpenseLineDetail",
+	TaxLine:            "TaxLineDetail",
+	ReimburseLine:      "ReimburseLineDetail",
+	DepositLine:        "DepositLineDetail",
+	JournalEntryLine:   "JournalEntryLineDetail",
+}
+
+// UnmarshalJSON decodes a Line. The detail of a DetailType this package does
+// not model is kept in RawDetail.
+func (l *Line) UnmarshalJSON(data []byte) error {
+	type line Line
+	if err := json.Unmarshal(data, (*line)(l)); err != nil {
+		return err
+	}
+
+	l.RawDetail = nil
+	if _, ok := lineDetailKeys[l.DetailType]; ok || l.DetailType == "" {
+		return nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	l.RawDetail = object[string(l.DetailType)]
+
+	return nil
+}
+
+// MarshalJSON encodes a Line with the detail matching its DetailType only,
+// or RawDetail when the type is not modeled.
+func (l Line) MarshalJSON() ([]byte, error) {
+	type line Line
+	data, err := json.Marshal(line(l))
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	active, ok := lineDetailKeys[l.DetailType]
+	for _, key := range lineDetailKeys {
+		if key != active {
+			delete(object, key)
+		}
+	}
+	if !ok && l.DetailType != "" && l.RawDetail != nil {
+		object[string(l.DetailType)] = l.RawDetail
+	}
+
+	return json.Marshal(object)
+}
+
+// NewSalesItemLine returns a line selling qty of the item at unitPrice. Its
+// Amount is qty times unitPrice, rounded to the decimal places of the ISO
+// 4217 currency.
+func NewSalesItemLine(itemRef ReferenceType, qty, unitPrice json.Number, currency string) (Line, error) {
+	amount, err := multiply(qty, unitPrice, currency)
+	if err != nil {
+		return Line{}, err
+	}
+
+	return Line{
+		Amount:     amount,
+		DetailType: SalesItemLine,
+		SalesItemLineDetail: SalesItemLineDetail{
+			ItemRef:   itemRef,
+			Qty:       qty,
+			UnitPrice: unitPrice,
+		},
+	}, nil
+}
+
+// NewDescriptionLine returns a line holding only a description.
+func NewDescriptionLine(description string) Line {
+	return Line{
+		Description: description,
+		DetailType:  DescriptionLine,
+	}
+}
+
+// NewDiscountLine returns a discount of a fixed amount.
+func NewDiscountLine(amount json.Number) Line {
+	return Line{
+		Amount:     amount,
+		DetailType: DiscountLine,
+	}
+}
+
+// NewPercentDiscountLine returns a discount of percent of the lines above it.
+func NewPercentDiscountLine(percent json.Number) Line {
+	return Line{
+		DetailType: DiscountLine,
+		DiscountLineDetail: DiscountLineDetail{
+			PercentBased:    true,
+			DiscountPercent: percent,
+		},
+	}
+}
+
+// NewSubTotalLine returns a line summing the lines above it.
+func NewSubTotalLine() Line {
+	return Line{DetailType: SubTotalLine}
+}
+
+// NewItemExpenseLine returns an expense of qty of the item at unitPrice. Its
+// Amount is qty times unitPrice, rounded to the decimal places of the ISO
+// 4217 currency.
+func NewItemExpenseLine(itemRef ReferenceType, qty, unitPrice json.Number, currency string) (Line, error) {
+	amount, err := multiply(qty, unitPrice, currency)
+	if err != nil {
+		return Line{}, err
+	}
+
+	return Line{
+		Amount:     amount,
+		DetailType: ItemExpenseLine,
+		ItemBasedExpenseLineDetail: ItemBasedExpenseLineDetail{
+			ItemRef:   itemRef,
+			Qty:       qty,
+			UnitPrice: unitPrice,
+		},
+	}, nil
+}
+
+// NewAccountExpenseLine returns an expense of amount booked to the account.
+func NewAccountExpenseLine(accountRef ReferenceType, amount json.Number) Line {
+	return Line{
+		Amount:     amount,
+		DetailType: AccountExpenseLine,
+		AccountBasedExpenseLineDetail: AccountBasedExpenseLineDetail{
+			AccountRef: accountRef,
+		},
+	}
+}
+
+// NewDepositLine returns a deposit of amount from the account.
+func NewDepositLine(accountRef ReferenceType, amount json.Number) Line {
+	return Line{
+		Amount:     amount,
+		DetailType: DepositLine,
+		DepositLineDetail: DepositLineDetail{
+			AccountRef: accountRef,
+		},
+	}
+}
+
+// NewJournalEntryLine returns a debit or credit of amount to the account.
+func NewJournalEntryLine(postingType PostingTypeEnum, accountRef ReferenceType, amount json.Number) Line {
+	return Line{
+		Amount:     amount,
+		DetailType: JournalEntryLine,
+		JournalEntryLineDetail: JournalEntryLineDetail{
+			PostingType: postingType,
+			AccountRef:  accountRef,
+		},
+	}
+}
+
+// multiply returns a times b rounded to the decimal places of the currency.
+func multiply(a, b json.Number, currency string) (json.Number, error) {
+	x, err := ParseDecimal(a.String())
+	if err != nil {
+		return "", err
+	}
+	y, err := ParseDecimal(b.String())
+	if err != nil {
+		return "", err
+	}
+
+	return x.Mul(y).Round(CurrencyDecimals(currency)).Number(), nil
+}
@@ -0,0 +1,91 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineMarshalsActiveDetailOnly(t *testing.T) {
+	line, err := NewSalesItemLine(ReferenceType{Value: "1", Name: "Services"}, "3", "12.75", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("38.25"), line.Amount)
+
+	data, err := json.Marshal(line)
+	require.NoError(t, err)
+
+	var object map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &object))
+	assert.Contains(t, object, "SalesItemLineDetail")
+	for _, key := range lineDetailKeys {
+		if key != "SalesItemLineDetail" {
+			assert.NotContains(t, object, key)
+		}
+	}
+
+	deposit := NewDepositLine(ReferenceType{Value: "4"}, "20.00")
+	assert.Equal(t, "4", deposit.AccountRef.Value)
+
+	data, err = json.Marshal(deposit)
+	require.NoError(t, err)
+	object = nil
+	require.NoError(t, json.Unmarshal(data, &object))
+	var detail DepositLineDetail
+	require.NoError(t, json.Unmarshal(object["DepositLineDetail"], &detail))
+	assert.Equal(t, "4", detail.AccountRef.Value)
+	assert.NotContains(t, object, "AccountRef")
+
+	var decoded Line
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "4", decoded.AccountRef.Value)
+}
+
+func TestLineKeepsUnknownDetail(t *testing.T) {
+	data := []byte(`{"Id":"1","Amount":5,"DetailType":"TDSLineDetail","TDSLineDetail":{"TDSSectionTypeId":"2"}}`)
+
+	var line Line
+	require.NoError(t, json.Unmarshal(data, &line))
+	assert.Equal(t, LineDetailTypeEnum("TDSLineDetail"), line.DetailType)
+	assert.JSONEq(t, `{"TDSSectionTypeId":"2"}`, string(line.RawDetail))
+
+	encoded, err := json.Marshal(line)
+	require.NoError(t, err)
+
+	var object map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(encoded, &object))
+	assert.JSONEq(t, `{"TDSSectionTypeId":"2"}`, string(object["TDSLineDetail"]))
+}
+
+func TestMultiply(t *testing.T) {
+	amount, err := multiply("0.5", "0.25", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("0.13"), amount)
+
+	amount, err = multiply("3", "1e2", "")
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("300.00"), amount)
+
+	amount, err = multiply("3", "0.333", "KWD")
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("0.999"), amount)
+
+	amount, err = multiply("2.5", "101", "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("253"), amount)
+
+	_, err = NewSalesItemLine(ReferenceType{Value: "1"}, "", "1", "USD")
+	assert.EqualError(t, err, `invalid decimal ""`)
+
+	_, err = NewItemExpenseLine(ReferenceType{Value: "1"}, "1", "abc", "USD")
+	assert.EqualError(t, err, `invalid decimal "abc"`)
+}
+
+// mustLine returns the line a constructor built, panicking on its error.
+func mustLine(line Line, err error) Line {
+	if err != nil {
+		panic(err)
+	}
+	return line
+}
@@ -0,0 +1,102 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// currencyDecimals lists the ISO 4217 currencies whose minor unit is not a
+// hundredth.
+var currencyDecimals = map[string]int32{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// CurrencyDecimals returns the number of decimal places amounts in the ISO
+// 4217 currency are rounded to: 2 for most currencies, 0 for JPY, 3 for KWD.
+func CurrencyDecimals(currency string) int32 {
+	if places, ok := currencyDecimals[strings.ToUpper(currency)]; ok {
+		return places
+	}
+	return 2
+}
+
+// Money is an amount in a currency, such as the TotalAmt of an invoice in
+// its CurrencyRef, as returned by Invoice.TotalAmtMoney.
+type Money struct {
+	Amount Decimal
+	// Currency is the ISO 4217 code, such as "USD".
+	Currency string
+}
+
+// NewMoney returns the amount in the currency.
+func NewMoney(amount Decimal, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// moneyFromNumber returns the amount in the currency of the reference, or
+// with no currency when the reference is missing.
+func moneyFromNumber(amount json.Number, currency *ReferenceType) (Money, error) {
+	decimal, err := DecimalFromNumber(amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	var code string
+	if currency != nil {
+		code = currency.Value
+	}
+
+	return NewMoney(decimal, code), nil
+}
+
+// Round rounds the amount half away from zero to the currency's minor unit.
+func (m Money) Round() Money {
+	return Money{Amount: m.Amount.Round(CurrencyDecimals(m.Currency)), Currency: m.Currency}
+}
+
+// Add returns m + x. Both must be in the same currency.
+func (m Money) Add(x Money) (Money, error) {
+	if err := m.sameCurrency(x); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Add(x.Amount), Currency: m.Currency}, nil
+}
+
+// Sub returns m - x. Both must be in the same currency.
+func (m Money) Sub(x Money) (Money, error) {
+	if err := m.sameCurrency(x); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Sub(x.Amount), Currency: m.Currency}, nil
+}
+
+// Mul returns m times a quantity or rate, rounded to the currency's minor
+// unit.
+func (m Money) Mul(x Decimal) Money {
+	return Money{Amount: m.Amount.Mul(x), Currency: m.Currency}.Round()
+}
+
+// Cmp compares m and x like Decimal.Cmp. Both must be in the same currency.
+func (m Money) Cmp(x Money) (int, error) {
+	if err := m.sameCurrency(x); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(x.Amount), nil
+}
+
+// String returns the amount rounded to the currency followed by the
+// currency, such as "12.50 USD".
+func (m Money) String() string {
+	return m.Round().Amount.String() + " " + m.Currency
+}
+
+func (m Money) sameCurrency(x Money) error {
+	if !strings.EqualFold(m.Currency, x.Currency) {
+		return fmt.Errorf("currency mismatch: %s and %s", m.Currency, x.Currency)
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+package quickbooks
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// IDTokenLeeway is the clock skew tolerated when checking the expiry of an
+// ID token.
+const IDTokenLeeway = time.Minute
+
+// minKeyBits is the size below which ID token signing keys are rejected.
+const minKeyBits = 2048
+
+// IDTokenClaims are the claims of a verified "Sign in with Intuit" ID token.
+type IDTokenClaims struct {
+	Issuer   string   `json:"iss"`
+	Subject  string   `json:"sub"`
+	Audience audience `json:"aud"`
+	// RealmId is the company the user connected, when the accounting scope
+	// was granted.
+	RealmId       string `json:"realmid"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Nonce         string `json:"nonce"`
+	ExpiresAt     int64  `json:"exp"`
+	IssuedAt      int64  `json:"iat"`
+	AuthTime      int64  `json:"auth_time"`
+}
+
+// audience is the aud claim, which may be a single string or a list.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+func (a audience) contains(s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyIDToken verifies the RS256 signature of raw, the IdToken of a
+// BearerToken, against the keys published at the discovery document's
+// JwksUri, and checks its issuer, audience and expiry.
+//
+// The token's nonce must match nonce, the one passed to
+// FindAuthorizationUrlWithNonce, which guards against replayed tokens.
+func (c *Client) VerifyIDToken(ctx context.Context, raw string, nonce string) (*IDTokenClaims, error) {
+	if nonce == "" {
+		return nil, fmt.Errorf("missing nonce to verify the id token against")
+	}
+
+	header, payload, signature, signed, err := splitJWT(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var head struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(header, &head); err != nil {
+		return nil, fmt.Errorf("invalid id token header: %v", err)
+	}
+	if head.Alg != "RS256" {
+		return nil, fmt.Errorf("unsupported id token algorithm %q", head.Alg)
+	}
+
+	discovery, err := c.DiscoveryAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := c.jwks.key(ctx, c.Client, discovery.JwksUri, head.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(signed))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("invalid id token signature: %v", err)
+	}
+
+	var claims IDTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("invalid id token claims: %v", err)
+	}
+
+	if claims.Issuer != discovery.Issuer {
+		return nil, fmt.Errorf("id token issued by %q, expected %q", claims.Issuer, discovery.Issuer)
+	}
+	if !claims.Audience.contains(c.clientId) {
+		return nil, fmt.Errorf("id token not issued for client %q", c.clientId)
+	}
+	if time.Now().After(time.Unix(claims.ExpiresAt, 0).Add(IDTokenLeeway)) {
+		return nil, fmt.Errorf("id token expired at %s", time.Unix(claims.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	}
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("id token nonce mismatch")
+	}
+
+	return &claims, nil
+}
+
+// splitJWT decodes the parts of a compact JWT, also returning the signed
+// header.payload string.
+func splitJWT(raw string) (header, payload, signature []byte, signed string, err error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, nil, nil, "", fmt.Errorf("malformed id token")
+	}
+
+	decoded := make([][]byte, 3)
+	for i, part := range parts {
+		if decoded[i], err = base64.RawURLEncoding.DecodeString(part); err != nil {
+			return nil, nil, nil, "", fmt.Errorf("malformed id token: %v", err)
+		}
+	}
+
+	return decoded[0], decoded[1], decoded[2], parts[0] + "." + parts[1], nil
+}
+
+// jwksRefetchInterval is the shortest time between two fetches of the key
+// set caused by tokens signed with an unknown key.
+const jwksRefetchInterval = time.Minute
+
+// jwksCache holds a Client's ID token signing keys by key id.
+type jwksCache struct {
+	group singleflight.Group
+
+	mu      sync.Mutex
+	uri     string
+	keys    map[string]*rsa.PublicKey
+	expires time.Time
+	fetched time.Time
+}
+
+// key returns the signing key with the given id, fetching the key set when
+// the cached copy has expired or lacks the key, as happens after Intuit
+// rotates its keys. A missing key refetches the set at most once every
+// jwksRefetchInterval, and concurrent callers share a single fetch.
+func (j *jwksCache) key(ctx context.Context, client *http.Client, uri, kid string) (*rsa.PublicKey, error) {
+	j.mu.Lock()
+	cached := j.uri == uri && time.Now().Before(j.expires)
+	key, ok := j.keys[kid]
+	throttled := cached && time.Since(j.fetched) < jwksRefetchInterval
+	j.mu.Unlock()
+
+	if cached && ok {
+		return checkKeySize(kid, key)
+	}
+	if throttled {
+		return nil, fmt.Errorf("unknown id token signing key %q", kid)
+	}
+
+	// The fetch is shared by every waiting caller, so it must not be cut
+	// short when the caller that started it gives up.
+	ch := j.group.DoChan(uri, func() (interface{}, error) {
+		keys, ttl, err := fetchJWKS(context.WithoutCancel(ctx), client, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		j.mu.Lock()
+		j.uri = uri
+		j.keys = keys
+		j.fetched = time.Now()
+		j.expires = j.fetched.Add(ttl)
+		j.mu.Unlock()
+
+		return keys, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		key, ok := res.Val.(map[string]*rsa.PublicKey)[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown id token signing key %q", kid)
+		}
+		return checkKeySize(kid, key)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// checkKeySize rejects signing keys shorter than minKeyBits.
+func checkKeySize(kid string, key *rsa.PublicKey) (*rsa.PublicKey, error) {
+	if bits := key.N.BitLen(); bits < minKeyBits {
+		return nil, fmt.Errorf("id token signing key %q is %d bits, less than %d", kid, bits, minKeyBits)
+	}
+	return key, nil
+}
+
+// fetchJWKS fetches the RSA keys of a JSON Web Key Set, returning how long
+// they may be cached. Keys shorter than minKeyBits are kept so that tokens
+// they signed are rejected for it by key, without failing the other keys.
+func fetchJWKS(ctx context.Context, client *http.Client, uri string) (map[string]*rsa.PublicKey, time.Duration, error) {
+	if uri == "" {
+		return nil, 0, fmt.Errorf("missing jwks_uri in discovery document")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create req: %v", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to make req: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)}
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &set); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal jwks: %v", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid modulus of key %q: %v", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid exponent of key %q: %v", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	return keys, cacheTTL(resp.Header.Get("Cache-Control")), nil
+}
+
+// UserInfo is the profile returned by the userinfo endpoint. Which fields
+// are filled in depends on the scopes granted: email, phone, profile and
+// address.
+type UserInfo struct {
+	Sub                 string `json:"sub"`
+	Email               string `json:"email"`
+	EmailVerified       bool   `json:"emailVerified"`
+	GivenName           string `json:"givenName"`
+	FamilyName          string `json:"familyName"`
+	PhoneNumber         string `json:"phoneNumber"`
+	PhoneNumberVerified bool   `json:"phoneNumberVerified"`
+	Address             struct {
+		StreetAddress string `json:"streetAddress"`
+		Locality      string `json:"locality"`
+		Region        string `json:"region"`
+		PostalCode    string `json:"postalCode"`
+		Country       string `json:"country"`
+	} `json:"address"`
+}
+
+// GetUserInfo retrieves the profile of the user who authorized token.
+func (c *Client) GetUserInfo(ctx context.Context, token *BearerToken) (*UserInfo, error) {
+	discovery, err := c.DiscoveryAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", discovery.UserinfoEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, failureFromBody(resp, body)
+	}
+
+	var info UserInfo
+
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &info, nil
+}
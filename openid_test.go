@@ -0,0 +1,180 @@
+package quickbooks
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signTestJWT(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var kid atomic.Value
+	kid.Store("key-1")
+	var jwksFetches atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/keys":
+			jwksFetches.Add(1)
+			json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": kid.Load().(string),
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}}})
+		case "/userinfo":
+			if r.Header.Get("Authorization") != "Bearer access" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"sub":"user-1","email":"ada@example.com","emailVerified":true,"givenName":"Ada"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientRequest{
+		Client:   server.Client(),
+		ClientId: "client-id",
+		DiscoveryAPI: &DiscoveryAPI{
+			Issuer:           "https://oauth.platform.intuit.com/op/v1",
+			JwksUri:          server.URL + "/keys",
+			UserinfoEndpoint: server.URL + "/userinfo",
+		},
+	})
+	require.NoError(t, err)
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss":     "https://oauth.platform.intuit.com/op/v1",
+			"sub":     "user-1",
+			"aud":     []string{"client-id"},
+			"realmid": "123",
+			"email":   "ada@example.com",
+			"nonce":   "n-1",
+			"exp":     time.Now().Add(time.Hour).Unix(),
+			"iat":     time.Now().Unix(),
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	ctx := context.Background()
+
+	verified, err := client.VerifyIDToken(ctx, signTestJWT(t, key, "key-1", claims(nil)), "n-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", verified.Subject)
+	assert.Equal(t, "123", verified.RealmId)
+	assert.Equal(t, "ada@example.com", verified.Email)
+
+	_, err = client.VerifyIDToken(ctx, signTestJWT(t, key, "key-1", claims(map[string]interface{}{"aud": "other"})), "n-1")
+	assert.EqualError(t, err, `id token not issued for client "client-id"`)
+
+	_, err = client.VerifyIDToken(ctx, signTestJWT(t, key, "key-1", claims(map[string]interface{}{"iss": "https://evil.example.com"})), "n-1")
+	assert.ErrorContains(t, err, "id token issued by")
+
+	_, err = client.VerifyIDToken(ctx, signTestJWT(t, key, "key-1", claims(map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()})), "n-1")
+	assert.ErrorContains(t, err, "id token expired")
+
+	_, err = client.VerifyIDToken(ctx, signTestJWT(t, key, "key-1", claims(nil)), "n-2")
+	assert.EqualError(t, err, "id token nonce mismatch")
+
+	_, err = client.VerifyIDToken(ctx, signTestJWT(t, key, "key-1", claims(nil)), "")
+	assert.EqualError(t, err, "missing nonce to verify the id token against")
+
+	tampered := signTestJWT(t, key, "key-1", claims(nil))
+	parts := strings.Split(tampered, ".")
+	payload, _ := json.Marshal(claims(map[string]interface{}{"realmid": "456"}))
+	parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+	_, err = client.VerifyIDToken(ctx, strings.Join(parts, "."), "n-1")
+	assert.ErrorContains(t, err, "invalid id token signature")
+
+	assert.Equal(t, int32(1), jwksFetches.Load())
+
+	// A token signed with a rotated key refetches the key set, but no more
+	// than once every jwksRefetchInterval.
+	kid.Store("key-2")
+	_, err = client.VerifyIDToken(ctx, signTestJWT(t, key, "key-2", claims(nil)), "n-1")
+	assert.EqualError(t, err, `unknown id token signing key "key-2"`)
+	assert.Equal(t, int32(1), jwksFetches.Load())
+
+	client.jwks.fetched = time.Now().Add(-jwksRefetchInterval)
+	_, err = client.VerifyIDToken(ctx, signTestJWT(t, key, "key-2", claims(nil)), "n-1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), jwksFetches.Load())
+
+	info, err := client.GetUserInfo(ctx, &BearerToken{AccessToken: "access"})
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", info.Sub)
+	assert.True(t, info.EmailVerified)
+	assert.Equal(t, "Ada", info.GivenName)
+
+	_, err = client.GetUserInfo(ctx, &BearerToken{AccessToken: "expired"})
+	var httpErr HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+}
+
+func TestJWKSRejectsShortKeysOnly(t *testing.T) {
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	strong, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwk := func(kid string, key *rsa.PrivateKey) map[string]string {
+		return map[string]string{
+			"kty": "RSA",
+			"kid": kid,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{jwk("weak", weak), jwk("strong", strong)}})
+	}))
+	defer server.Close()
+
+	var cache jwksCache
+	_, err = cache.key(context.Background(), server.Client(), server.URL, "weak")
+	assert.EqualError(t, err, `id token signing key "weak" is 1024 bits, less than 2048`)
+
+	key, err := cache.key(context.Background(), server.Client(), server.URL, "strong")
+	require.NoError(t, err)
+	assert.True(t, key.Equal(&strong.PublicKey))
+
+	_, err = cache.key(context.Background(), server.Client(), server.URL, "weak")
+	assert.Error(t, err)
+}
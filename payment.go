@@ -14,8 +14,8 @@ type Payment struct {
 	ProjectRef          ReferenceType        `json:",omitempty"`
 	PaymentMethodRef    *ReferenceType       `json:",omitempty"`
 	TaxExemptionRef     *ReferenceType       `json:",omitempty"`
-	TxnDate             Date                 `json:",omitempty"`
-	MetaData            ModificationMetaData `json:",omitempty"`
+	TxnDate             Date                 `json:",omitzero"`
+	MetaData            ModificationMetaData `json:",omitzero"`
 	ExchangeRate        json.Number          `json:",omitempty"`
 	UnappliedAmt        json.Number          `json:",omitempty"`
 	TotalAmt            json.Number          `json:",omitempty"`
@@ -28,6 +28,21 @@ type Payment struct {
 	// CreditCardPayment
 	// TransactionLocationType
 	// PaymentRefNum
+
+	// Unknown holds the fields QuickBooks sent that Payment does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a Payment, keeping the fields it does not model in Unknown.
+func (p *Payment) UnmarshalJSON(data []byte) error {
+	type payment Payment
+	return unmarshalWithUnknown(data, (*payment)(p), &p.Unknown)
+}
+
+// MarshalJSON encodes a Payment along with its Unknown fields.
+func (p Payment) MarshalJSON() ([]byte, error) {
+	type payment Payment
+	return marshalWithUnknown(payment(p), p.Unknown)
 }
 
 // CreatePayment creates the given payment within QuickBooks.
@@ -55,36 +70,7 @@ func (c *Client) DeletePayment(params RequestParameters, payment *Payment) error
 
 // FindPayments gets the full list of Payments in the QuickBooks account.
 func (c *Client) FindPayments(params RequestParameters) ([]Payment, error) {
-	var resp struct {
-		QueryResponse struct {
-			Payments      []Payment `json:"Payment"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Payment", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	payments := make([]Payment, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Payment ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		payments = append(payments, resp.QueryResponse.Payments...)
-	}
-
-	return payments, nil
+	return queryAll[Payment](c, params, "")
 }
 
 func (c *Client) FindPaymentsByPage(params RequestParameters, startPosition, pageSize int) ([]Payment, error) {
@@ -121,7 +107,7 @@ func (c *Client) FindPaymentById(params RequestParameters, id string) (*Payment,
 }
 
 // QueryPayments accepts a SQL query and returns all payments found using it.
-func (c *Client) QueryPayments(params RequestParameters, query string) ([]Payment, error) {
+func (c *Client) QueryPayments(params RequestParameters, query QueryStatement) ([]Payment, error) {
 	var resp struct {
 		QueryResponse struct {
 			Payments      []Payment `json:"Payment"`
@@ -130,7 +116,7 @@ func (c *Client) QueryPayments(params RequestParameters, query string) ([]Paymen
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -143,12 +129,14 @@ func (c *Client) UpdatePayment(params RequestParameters, payment *Payment) (*Pay
 		return nil, errors.New("missing payment id")
 	}
 
-	existingPayment, err := c.FindPaymentById(params, payment.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingPayment, err := c.FindPaymentById(params, payment.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	payment.SyncToken = existingPayment.SyncToken
+		payment.SyncToken = existingPayment.SyncToken
+	}
 
 	payload := struct {
 		*Payment
@@ -161,11 +149,11 @@ func (c *Client) UpdatePayment(params RequestParameters, payment *Payment) (*Pay
 		Time    Date
 	}
 
-	if err = c.post(params, "payment", payload, &paymentData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "payment", payload, &paymentData, nil); err != nil {
+		return nil, conflictError(params, payment.Id, payment, err, c.FindPaymentById)
 	}
 
-	return &paymentData.Payment, err
+	return &paymentData.Payment, nil
 }
 
 // VoidPayment voids the given payment in QuickBooks.
@@ -6,7 +6,7 @@ import (
 )
 
 type PaymentMethod struct {
-	MetaData  ModificationMetaData `json:",omitempty"`
+	MetaData  ModificationMetaData `json:",omitzero"`
 	Id        string               `json:",omitempty"`
 	Name      string               `json:",omitempty"`
 	SyncToken string               `json:",omitempty"`
@@ -14,6 +14,21 @@ type PaymentMethod struct {
 	Active    bool                 `json:",omitempty"`
 	Domain    string               `json:"domain,omitempty"`
 	Status    string               `json:"status,omitempty"`
+
+	// Unknown holds the fields QuickBooks sent that PaymentMethod does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a PaymentMethod, keeping the fields it does not model in Unknown.
+func (p *PaymentMethod) UnmarshalJSON(data []byte) error {
+	type paymentMethod PaymentMethod
+	return unmarshalWithUnknown(data, (*paymentMethod)(p), &p.Unknown)
+}
+
+// MarshalJSON encodes a PaymentMethod along with its Unknown fields.
+func (p PaymentMethod) MarshalJSON() ([]byte, error) {
+	type paymentMethod PaymentMethod
+	return marshalWithUnknown(paymentMethod(p), p.Unknown)
 }
 
 // CreatePaymentMethod creates the given PaymentMethod on the QuickBooks server, returning
@@ -33,36 +48,7 @@ func (c *Client) CreatePaymentMethod(params RequestParameters, paymentMethod *Pa
 
 // FindPaymentMethods gets the full list of PaymentMethods in the QuickBooks account.
 func (c *Client) FindPaymentMethods(params RequestParameters) ([]PaymentMethod, error) {
-	var resp struct {
-		QueryResponse struct {
-			PaymentMethods []PaymentMethod `json:"PaymentMethod"`
-			MaxResults     int
-			StartPosition  int
-			TotalCount     int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM PaymentMethod", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	paymentMethods := make([]PaymentMethod, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM PaymentMethod ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		paymentMethods = append(paymentMethods, resp.QueryResponse.PaymentMethods...)
-	}
-
-	return paymentMethods, nil
+	return queryAll[PaymentMethod](c, params, "")
 }
 
 func (c *Client) FindPaymentMethodsByPage(params RequestParameters, startPosition, pageSize int) ([]PaymentMethod, error) {
@@ -99,7 +85,7 @@ func (c *Client) FindPaymentMethodById(params RequestParameters, id string) (*Pa
 }
 
 // QueryPaymentMethods accepts an SQL query and returns all estimates found using it
-func (c *Client) QueryPaymentMethods(params RequestParameters, query string) ([]PaymentMethod, error) {
+func (c *Client) QueryPaymentMethods(params RequestParameters, query QueryStatement) ([]PaymentMethod, error) {
 	var resp struct {
 		QueryResponse struct {
 			PaymentMethods []PaymentMethod `json:"PaymentMethod"`
@@ -108,7 +94,7 @@ func (c *Client) QueryPaymentMethods(params RequestParameters, query string) ([]
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -121,12 +107,14 @@ func (c *Client) UpdatePaymentMethod(params RequestParameters, paymentMethod *Pa
 		return nil, errors.New("missing estimate id")
 	}
 
-	existingPaymentMethod, err := c.FindPaymentMethodById(params, paymentMethod.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingPaymentMethod, err := c.FindPaymentMethodById(params, paymentMethod.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	paymentMethod.SyncToken = existingPaymentMethod.SyncToken
+		paymentMethod.SyncToken = existingPaymentMethod.SyncToken
+	}
 
 	payload := struct {
 		*PaymentMethod
@@ -139,9 +127,9 @@ func (c *Client) UpdatePaymentMethod(params RequestParameters, paymentMethod *Pa
 		Time          Date
 	}
 
-	if err = c.post(params, "estimate", payload, &paymentMethodData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "estimate", payload, &paymentMethodData, nil); err != nil {
+		return nil, conflictError(params, paymentMethod.Id, paymentMethod, err, c.FindPaymentMethodById)
 	}
 
-	return &paymentMethodData.PaymentMethod, err
+	return &paymentMethodData.PaymentMethod, nil
 }
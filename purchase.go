@@ -18,7 +18,7 @@ type Purchase struct {
 	RecurDataRef     *ReferenceType       `json:",omitempty"`
 	RemitToAddr      *PhysicalAddress     `json:",omitempty"`
 	TxnDate          *Date                `json:",omitempty"`
-	MetaData         ModificationMetaData `json:",omitempty"`
+	MetaData         ModificationMetaData `json:",omitzero"`
 	ExchangeRate     json.Number          `json:",omitempty"`
 	TotalAmt         json.Number          `json:",omitempty"`
 	Id               string               `json:",omitempty"`
@@ -33,6 +33,21 @@ type Purchase struct {
 	// GlobalTaxCalculation
 	// TransactionLocationType
 	// IncludeInAnnualTPAR
+
+	// Unknown holds the fields QuickBooks sent that Purchase does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a Purchase, keeping the fields it does not model in Unknown.
+func (p *Purchase) UnmarshalJSON(data []byte) error {
+	type purchase Purchase
+	return unmarshalWithUnknown(data, (*purchase)(p), &p.Unknown)
+}
+
+// MarshalJSON encodes a Purchase along with its Unknown fields.
+func (p Purchase) MarshalJSON() ([]byte, error) {
+	type purchase Purchase
+	return marshalWithUnknown(purchase(p), p.Unknown)
 }
 
 // CreatePurchase creates the given Purchase on the QuickBooks server, returning
@@ -61,36 +76,7 @@ func (c *Client) DeletePurchase(params RequestParameters, purchase *Purchase) er
 
 // FindPurchases gets the full list of Purchases in the QuickBooks account.
 func (c *Client) FindPurchases(params RequestParameters) ([]Purchase, error) {
-	var resp struct {
-		QueryResponse struct {
-			Purchases     []Purchase `json:"Purchase"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Purchase", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	purchases := make([]Purchase, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Purchase ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		purchases = append(purchases, resp.QueryResponse.Purchases...)
-	}
-
-	return purchases, nil
+	return queryAll[Purchase](c, params, "")
 }
 
 func (c *Client) FindPurchasesByPage(params RequestParameters, startPosition, pageSize int) ([]Purchase, error) {
@@ -127,7 +113,7 @@ func (c *Client) FindPurchaseById(params RequestParameters, id string) (*Purchas
 }
 
 // QueryPurchases accepts an SQL query and returns all purchases found using it
-func (c *Client) QueryPurchases(params RequestParameters, query string) ([]Purchase, error) {
+func (c *Client) QueryPurchases(params RequestParameters, query QueryStatement) ([]Purchase, error) {
 	var resp struct {
 		QueryResponse struct {
 			Purchases     []Purchase `json:"Purchase"`
@@ -136,7 +122,7 @@ func (c *Client) QueryPurchases(params RequestParameters, query string) ([]Purch
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -149,12 +135,14 @@ func (c *Client) UpdatePurchase(params RequestParameters, purchase *Purchase) (*
 		return nil, errors.New("missing purchase id")
 	}
 
-	existingPurchase, err := c.FindPurchaseById(params, purchase.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingPurchase, err := c.FindPurchaseById(params, purchase.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	purchase.SyncToken = existingPurchase.SyncToken
+		purchase.SyncToken = existingPurchase.SyncToken
+	}
 
 	payload := struct {
 		*Purchase
@@ -167,9 +155,9 @@ func (c *Client) UpdatePurchase(params RequestParameters, purchase *Purchase) (*
 		Time     Date
 	}
 
-	if err = c.post(params, "purchase", payload, &purchaseData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "purchase", payload, &purchaseData, nil); err != nil {
+		return nil, conflictError(params, purchase.Id, purchase, err, c.FindPurchaseById)
 	}
 
-	return &purchaseData.Purchase, err
+	return &purchaseData.Purchase, nil
 }
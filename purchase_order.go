@@ -0,0 +1,441 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type POStatusEnum string
+
+const (
+	OpenPOStatus   POStatusEnum = "Open"
+	ClosedPOStatus POStatusEnum = "Closed"
+)
+
+// PurchaseOrder represents a QuickBooks PurchaseOrder object. Its lines are
+// ItemExpenseLine and AccountExpenseLine lines, billed as goods are received
+// by Bills linking back to them; see ConvertPurchaseOrderToBill. ShipTo is
+// the customer or vendor the goods are shipped to.
+type PurchaseOrder struct {
+	Line                 []Line        `json:",omitempty"`
+	LinkedTxn            []LinkedTxn   `json:",omitempty"`
+	CustomField          []CustomField `json:",omitempty"`
+	TxnTaxDetail         *TxnTaxDetail `json:",omitempty"`
+	VendorRef            ReferenceType
+	APAccountRef         *ReferenceType       `json:",omitempty"`
+	ClassRef             *ReferenceType       `json:",omitempty"`
+	SalesTermRef         *ReferenceType       `json:",omitempty"`
+	ShipMethodRef        *ReferenceType       `json:",omitempty"`
+	RecurDataRef         *ReferenceType       `json:",omitempty"`
+	ShipTo               *ReferenceType       `json:",omitempty"`
+	CurrencyRef          ReferenceType        `json:",omitempty"`
+	VendorAddr           *PhysicalAddress     `json:",omitempty"`
+	ShipAddr             *PhysicalAddress     `json:",omitempty"`
+	POEmail              *EmailAddress        `json:",omitempty"`
+	TxnDate              Date                 `json:",omitzero"`
+	DueDate              Date                 `json:",omitzero"`
+	MetaData             ModificationMetaData `json:",omitzero"`
+	ExchangeRate         json.Number          `json:",omitempty"`
+	TotalAmt             json.Number          `json:",omitempty"`
+	Id                   string               `json:",omitempty"`
+	SyncToken            string               `json:",omitempty"`
+	DocNumber            string               `json:",omitempty"`
+	PrivateNote          string               `json:",omitempty"`
+	Memo                 string               `json:",omitempty"`
+	POStatus             POStatusEnum         `json:",omitempty"`
+	EmailStatus          string               `json:",omitempty"`
+	GlobalTaxCalculation string               `json:",omitempty"`
+	Domain               string               `json:"domain,omitempty"`
+	Status               string               `json:"status,omitempty"`
+	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that PurchaseOrder does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a PurchaseOrder, keeping the fields it does not model in Unknown.
+func (p *PurchaseOrder) UnmarshalJSON(data []byte) error {
+	type purchaseOrder PurchaseOrder
+	return unmarshalWithUnknown(data, (*purchaseOrder)(p), &p.Unknown)
+}
+
+// MarshalJSON encodes a PurchaseOrder along with its Unknown fields.
+func (p PurchaseOrder) MarshalJSON() ([]byte, error) {
+	type purchaseOrder PurchaseOrder
+	return marshalWithUnknown(purchaseOrder(p), p.Unknown)
+}
+
+// CreatePurchaseOrder creates the given PurchaseOrder on the QuickBooks server,
+// returning the resulting PurchaseOrder object.
+func (c *Client) CreatePurchaseOrder(params RequestParameters, purchaseOrder *PurchaseOrder) (*PurchaseOrder, error) {
+	var resp struct {
+		PurchaseOrder PurchaseOrder
+		Time          Date
+	}
+
+	if err := c.post(params, "purchaseorder", purchaseOrder, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return &resp.PurchaseOrder, nil
+}
+
+// DeletePurchaseOrder deletes the purchase order.
+func (c *Client) DeletePurchaseOrder(params RequestParameters, purchaseOrder *PurchaseOrder) error {
+	if purchaseOrder.Id == "" || purchaseOrder.SyncToken == "" {
+		return errors.New("missing id/sync token")
+	}
+
+	return c.post(params, "purchaseorder", purchaseOrder, nil, map[string]string{"operation": "delete"})
+}
+
+// FindPurchaseOrders gets the full list of PurchaseOrders in the QuickBooks account.
+func (c *Client) FindPurchaseOrders(params RequestParameters) ([]PurchaseOrder, error) {
+	return queryAll[PurchaseOrder](c, params, "")
+}
+
+func (c *Client) FindPurchaseOrdersByPage(params RequestParameters, startPosition, pageSize int) ([]PurchaseOrder, error) {
+	var resp struct {
+		QueryResponse struct {
+			PurchaseOrders []PurchaseOrder `json:"PurchaseOrder"`
+			MaxResults     int
+			StartPosition  int
+			TotalCount     int
+		}
+	}
+
+	query := "SELECT * FROM PurchaseOrder ORDERBY Id STARTPOSITION " + strconv.Itoa(startPosition) + " MAXRESULTS " + strconv.Itoa(pageSize)
+
+	if err := c.query(params, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QueryResponse.PurchaseOrders, nil
+}
+
+// FindPurchaseOrderById finds the purchase order by the given id
+func (c *Client) FindPurchaseOrderById(params RequestParameters, id string) (*PurchaseOrder, error) {
+	var resp struct {
+		PurchaseOrder PurchaseOrder
+		Time          Date
+	}
+
+	if err := c.get(params, "purchaseorder/"+id, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return &resp.PurchaseOrder, nil
+}
+
+// QueryPurchaseOrders accepts an SQL query and returns all purchase orders found using it
+func (c *Client) QueryPurchaseOrders(params RequestParameters, query QueryStatement) ([]PurchaseOrder, error) {
+	var resp struct {
+		QueryResponse struct {
+			PurchaseOrders []PurchaseOrder `json:"PurchaseOrder"`
+			StartPosition  int
+			MaxResults     int
+		}
+	}
+
+	if err := c.queryStatement(params, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QueryResponse.PurchaseOrders, nil
+}
+
+// SendPurchaseOrder sends the purchase order to the PurchaseOrder.POEmail if emailAddress is left empty
+func (c *Client) SendPurchaseOrder(params RequestParameters, purchaseOrderId, emailAddress string) error {
+	queryParameters := make(map[string]string)
+
+	if emailAddress != "" {
+		queryParameters["sendTo"] = emailAddress
+	}
+
+	return c.post(params, "purchaseorder/"+purchaseOrderId+"/send", nil, nil, queryParameters)
+}
+
+// UpdatePurchaseOrder full updates the purchase order, meaning that missing writable fields will be set to nil/null
+func (c *Client) UpdatePurchaseOrder(params RequestParameters, purchaseOrder *PurchaseOrder) (*PurchaseOrder, error) {
+	if purchaseOrder.Id == "" {
+		return nil, errors.New("missing purchase order id")
+	}
+
+	if !params.StrictConcurrency {
+		existingPurchaseOrder, err := c.FindPurchaseOrderById(params, purchaseOrder.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		purchaseOrder.SyncToken = existingPurchaseOrder.SyncToken
+	}
+
+	payload := struct {
+		*PurchaseOrder
+	}{
+		PurchaseOrder: purchaseOrder,
+	}
+
+	var purchaseOrderData struct {
+		PurchaseOrder PurchaseOrder
+		Time          Date
+	}
+
+	if err := c.post(params, "purchaseorder", payload, &purchaseOrderData, nil); err != nil {
+		return nil, conflictError(params, purchaseOrder.Id, purchaseOrder, err, c.FindPurchaseOrderById)
+	}
+
+	return &purchaseOrderData.PurchaseOrder, nil
+}
+
+// SparseUpdatePurchaseOrder updates only fields included in the purchase order struct, other fields are left unmodified
+func (c *Client) SparseUpdatePurchaseOrder(params RequestParameters, purchaseOrder *PurchaseOrder) (*PurchaseOrder, error) {
+	if purchaseOrder.Id == "" {
+		return nil, errors.New("missing purchase order id")
+	}
+
+	if !params.StrictConcurrency {
+		existingPurchaseOrder, err := c.FindPurchaseOrderById(params, purchaseOrder.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		purchaseOrder.SyncToken = existingPurchaseOrder.SyncToken
+	}
+
+	payload := sparsePayload{purchaseOrder}
+
+	var purchaseOrderData struct {
+		PurchaseOrder PurchaseOrder
+		Time          Date
+	}
+
+	if err := c.post(params, "purchaseorder", payload, &purchaseOrderData, nil); err != nil {
+		return nil, conflictError(params, purchaseOrder.Id, purchaseOrder, err, c.FindPurchaseOrderById)
+	}
+
+	return &purchaseOrderData.PurchaseOrder, nil
+}
+
+// PurchaseOrderLineStatus is how much of a purchase order line has been
+// billed. Ordered, Received and Open are quantities for item lines and
+// amounts for account lines.
+type PurchaseOrderLineStatus struct {
+	Line     Line
+	Ordered  Decimal
+	Received Decimal
+	Open     Decimal
+}
+
+// ConvertPurchaseOrderToBill returns a Bill for goods received against the
+// purchase order, each line linking back to the purchase order line it
+// bills. received maps purchase order line ids to the quantity received,
+// or for account lines the amount; lines it leaves out are not billed. Item
+// lines are billed at their UnitPrice, or at their share of the line's
+// Amount when they have none, rounded to the purchase order's currency.
+//
+// It fails when a line would be billed for more than was ordered. To bill
+// what is left after earlier partial receipts, pass the OpenQuantities of
+// OpenPurchaseOrderLines.
+func ConvertPurchaseOrderToBill(purchaseOrder *PurchaseOrder, received map[string]json.Number) (*Bill, error) {
+	if received == nil {
+		return nil, fmt.Errorf("missing received quantities for purchase order %s", purchaseOrder.Id)
+	}
+
+	bill := &Bill{
+		VendorRef:    purchaseOrder.VendorRef,
+		APAccountRef: purchaseOrder.APAccountRef,
+		SalesTermRef: purchaseOrder.SalesTermRef,
+		CurrencyRef:  purchaseOrder.CurrencyRef,
+		ExchangeRate: purchaseOrder.ExchangeRate,
+	}
+
+	for _, line := range purchaseOrder.Line {
+		n, ok := received[line.Id]
+		if !ok {
+			continue
+		}
+
+		ordered, ok, err := orderedAmount(line)
+		if err != nil {
+			return nil, fmt.Errorf("purchase order %s: %v", purchaseOrder.Id, err)
+		}
+		if !ok {
+			continue
+		}
+
+		quantity, err := DecimalFromNumber(n)
+		if err != nil {
+			return nil, fmt.Errorf("invalid received quantity of line %s: %v", line.Id, err)
+		}
+		if quantity.Cmp(ordered) > 0 {
+			return nil, fmt.Errorf("received %s of purchase order line %s exceeds the %s ordered", quantity, line.Id, ordered)
+		}
+		if quantity.Sign() <= 0 {
+			continue
+		}
+
+		billLine := Line{
+			Description: line.Description,
+			DetailType:  line.DetailType,
+			LinkedTxn:   []LinkedTxn{{TxnId: purchaseOrder.Id, TxnType: "PurchaseOrder", TxnLineId: line.Id}},
+		}
+		switch line.DetailType {
+		case ItemExpenseLine:
+			billLine.ItemBasedExpenseLineDetail = line.ItemBasedExpenseLineDetail
+			billLine.ItemBasedExpenseLineDetail.Qty = quantity.Number()
+			if billLine.Amount, err = receivedAmount(line, quantity, ordered, purchaseOrder.CurrencyRef.Value); err != nil {
+				return nil, fmt.Errorf("purchase order %s: %v", purchaseOrder.Id, err)
+			}
+		case AccountExpenseLine:
+			billLine.AccountBasedExpenseLineDetail = line.AccountBasedExpenseLineDetail
+			billLine.Amount = quantity.Round(CurrencyDecimals(purchaseOrder.CurrencyRef.Value)).Number()
+		}
+
+		bill.Line = append(bill.Line, billLine)
+	}
+
+	if len(bill.Line) == 0 {
+		return nil, fmt.Errorf("nothing received against purchase order %s", purchaseOrder.Id)
+	}
+
+	return bill, nil
+}
+
+// receivedAmount returns what quantity of the ordered units of an item line
+// costs, rounded to the currency: quantity times the line's UnitPrice, or
+// without one, its Amount scaled by quantity over ordered.
+func receivedAmount(line Line, quantity, ordered Decimal, currency string) (json.Number, error) {
+	if price := line.ItemBasedExpenseLineDetail.UnitPrice; price != "" {
+		amount, err := multiply(quantity.Number(), price, currency)
+		if err != nil {
+			return "", fmt.Errorf("invalid UnitPrice of line %s: %v", line.Id, err)
+		}
+		return amount, nil
+	}
+
+	if line.Amount == "" {
+		return "", fmt.Errorf("line %s has neither a UnitPrice nor an Amount", line.Id)
+	}
+	amount, err := line.AmountDecimal()
+	if err != nil {
+		return "", fmt.Errorf("invalid Amount of line %s: %v", line.Id, err)
+	}
+
+	return amount.Mul(quantity).Div(ordered, CurrencyDecimals(currency)).Number(), nil
+}
+
+// orderedAmount returns the quantity ordered on an item line or the amount
+// of an account line. It reports false for other lines, and fails when the
+// quantity or amount is missing or invalid.
+func orderedAmount(line Line) (Decimal, bool, error) {
+	var field string
+	var n json.Number
+	switch line.DetailType {
+	case ItemExpenseLine:
+		field, n = "Qty", line.ItemBasedExpenseLineDetail.Qty
+	case AccountExpenseLine:
+		field, n = "Amount", line.Amount
+	default:
+		return Decimal{}, false, nil
+	}
+
+	if n == "" {
+		return Decimal{}, true, fmt.Errorf("line %s has no %s", line.Id, field)
+	}
+	ordered, err := DecimalFromNumber(n)
+	if err != nil {
+		return Decimal{}, true, fmt.Errorf("invalid %s of line %s: %v", field, line.Id, err)
+	}
+
+	return ordered, true, nil
+}
+
+// OpenPurchaseOrderLines returns the lines of the purchase order that bills
+// have not yet received in full. bills are the bills linked to the purchase
+// order; lines of other bills are ignored. A closed purchase order has no
+// open lines.
+func OpenPurchaseOrderLines(purchaseOrder *PurchaseOrder, bills []Bill) ([]PurchaseOrderLineStatus, error) {
+	if purchaseOrder.POStatus == ClosedPOStatus {
+		return nil, nil
+	}
+
+	received := make(map[string]Decimal)
+	for _, bill := range bills {
+		for _, line := range bill.Line {
+			for _, linked := range line.LinkedTxn {
+				if linked.TxnType != "PurchaseOrder" || linked.TxnId != purchaseOrder.Id {
+					continue
+				}
+				quantity, ok, err := orderedAmount(line)
+				if err != nil {
+					return nil, fmt.Errorf("bill %s: %v", bill.Id, err)
+				}
+				if ok {
+					received[linked.TxnLineId] = received[linked.TxnLineId].Add(quantity)
+				}
+			}
+		}
+	}
+
+	var open []PurchaseOrderLineStatus
+	for _, line := range purchaseOrder.Line {
+		ordered, ok, err := orderedAmount(line)
+		if err != nil {
+			return nil, fmt.Errorf("purchase order %s: %v", purchaseOrder.Id, err)
+		}
+		if !ok {
+			continue
+		}
+
+		status := PurchaseOrderLineStatus{
+			Line:     line,
+			Ordered:  ordered,
+			Received: received[line.Id],
+			Open:     ordered.Sub(received[line.Id]),
+		}
+		if status.Open.Sign() > 0 {
+			open = append(open, status)
+		}
+	}
+
+	return open, nil
+}
+
+// OpenQuantities maps the lines to their Open quantity or amount, for
+// ConvertPurchaseOrderToBill to bill everything still open.
+func OpenQuantities(open []PurchaseOrderLineStatus) map[string]json.Number {
+	received := make(map[string]json.Number, len(open))
+	for _, status := range open {
+		received[status.Line.Id] = status.Open.Number()
+	}
+	return received
+}
+
+// FindOpenPurchaseOrderLines returns the lines of the purchase order with the
+// given id that its linked bills have not yet received in full.
+func (c *Client) FindOpenPurchaseOrderLines(params RequestParameters, purchaseOrderId string) ([]PurchaseOrderLineStatus, error) {
+	purchaseOrder, err := c.FindPurchaseOrderById(params, purchaseOrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, linked := range purchaseOrder.LinkedTxn {
+		if linked.TxnType == "Bill" {
+			ids = append(ids, quoteQueryString(linked.TxnId))
+		}
+	}
+
+	var bills []Bill
+	if len(ids) > 0 {
+		if bills, err = c.QueryBills(params, RawQuery("SELECT * FROM Bill WHERE Id IN ("+strings.Join(ids, ", ")+")")); err != nil {
+			return nil, fmt.Errorf("failed to look up purchase order bills: %w", err)
+		}
+	}
+
+	return OpenPurchaseOrderLines(purchaseOrder, bills)
+}
@@ -0,0 +1,124 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPurchaseOrder() *PurchaseOrder {
+	purchaseOrder := &PurchaseOrder{
+		Id:        "40",
+		VendorRef: ReferenceType{Value: "46"},
+		POStatus:  OpenPOStatus,
+		Line: []Line{
+			mustLine(NewItemExpenseLine(ReferenceType{Value: "11"}, "10", "2.50", "")),
+			NewAccountExpenseLine(ReferenceType{Value: "7"}, "40.00"),
+		},
+		LinkedTxn: []LinkedTxn{{TxnId: "51", TxnType: "Bill"}},
+	}
+	purchaseOrder.Line[0].Id = "1"
+	purchaseOrder.Line[1].Id = "2"
+	return purchaseOrder
+}
+
+func TestConvertPurchaseOrderToBill(t *testing.T) {
+	purchaseOrder := testPurchaseOrder()
+
+	_, err := ConvertPurchaseOrderToBill(purchaseOrder, nil)
+	assert.EqualError(t, err, "missing received quantities for purchase order 40")
+
+	bill, err := ConvertPurchaseOrderToBill(purchaseOrder, map[string]json.Number{"1": "10", "2": "40.00"})
+	require.NoError(t, err)
+	assert.Equal(t, "46", bill.VendorRef.Value)
+	require.Len(t, bill.Line, 2)
+	assert.Equal(t, json.Number("25.00"), bill.Line[0].Amount)
+	assert.Equal(t, []LinkedTxn{{TxnId: "40", TxnType: "PurchaseOrder", TxnLineId: "1"}}, bill.Line[0].LinkedTxn)
+	assert.Equal(t, json.Number("40.00"), bill.Line[1].Amount)
+	assert.Equal(t, "7", bill.Line[1].AccountBasedExpenseLineDetail.AccountRef.Value)
+
+	partial, err := ConvertPurchaseOrderToBill(purchaseOrder, map[string]json.Number{"1": "4"})
+	require.NoError(t, err)
+	require.Len(t, partial.Line, 1)
+	assert.Equal(t, json.Number("4"), partial.Line[0].ItemBasedExpenseLineDetail.Qty)
+	assert.Equal(t, json.Number("10.00"), partial.Line[0].Amount)
+
+	// Without a UnitPrice the line's Amount is prorated, and amounts are
+	// rounded to the currency.
+	purchaseOrder.Line[0].ItemBasedExpenseLineDetail.UnitPrice = ""
+	purchaseOrder.Line[0].Amount = "25.00"
+	partial, err = ConvertPurchaseOrderToBill(purchaseOrder, map[string]json.Number{"1": "3"})
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("7.50"), partial.Line[0].Amount)
+
+	purchaseOrder.CurrencyRef = ReferenceType{Value: "JPY"}
+	partial, err = ConvertPurchaseOrderToBill(purchaseOrder, map[string]json.Number{"1": "3", "2": "12.5"})
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("8"), partial.Line[0].Amount)
+	assert.Equal(t, json.Number("13"), partial.Line[1].Amount)
+
+	purchaseOrder.Line[0].Amount = ""
+	_, err = ConvertPurchaseOrderToBill(purchaseOrder, map[string]json.Number{"1": "3"})
+	assert.EqualError(t, err, "purchase order 40: line 1 has neither a UnitPrice nor an Amount")
+
+	purchaseOrder = testPurchaseOrder()
+	purchaseOrder.Line[0].ItemBasedExpenseLineDetail.UnitPrice = "2.555"
+	partial, err = ConvertPurchaseOrderToBill(purchaseOrder, map[string]json.Number{"1": "3"})
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("7.67"), partial.Line[0].Amount)
+
+	_, err = ConvertPurchaseOrderToBill(purchaseOrder, map[string]json.Number{"1": "11"})
+	assert.EqualError(t, err, "received 11 of purchase order line 1 exceeds the 10 ordered")
+
+	_, err = ConvertPurchaseOrderToBill(purchaseOrder, map[string]json.Number{})
+	assert.EqualError(t, err, "nothing received against purchase order 40")
+
+	purchaseOrder.Line[0].ItemBasedExpenseLineDetail.Qty = ""
+	_, err = ConvertPurchaseOrderToBill(purchaseOrder, map[string]json.Number{"1": "4"})
+	assert.EqualError(t, err, "purchase order 40: line 1 has no Qty")
+
+	_, err = OpenPurchaseOrderLines(purchaseOrder, nil)
+	assert.EqualError(t, err, "purchase order 40: line 1 has no Qty")
+}
+
+func TestFindOpenPurchaseOrderLines(t *testing.T) {
+	purchaseOrder := testPurchaseOrder()
+	received, err := ConvertPurchaseOrderToBill(purchaseOrder, map[string]json.Number{"1": "4", "2": "40"})
+	require.NoError(t, err)
+	received.Id = "51"
+
+	var query string
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/company/1234/purchaseorder/40":
+			json.NewEncoder(w).Encode(map[string]interface{}{"PurchaseOrder": purchaseOrder})
+		case "/v3/company/1234/query":
+			query = r.URL.Query().Get("query")
+			json.NewEncoder(w).Encode(map[string]interface{}{"QueryResponse": map[string]interface{}{"Bill": []*Bill{received}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	open, err := client.FindOpenPurchaseOrderLines(params, "40")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM Bill WHERE Id IN ('51')", query)
+	require.Len(t, open, 1)
+	assert.Equal(t, "1", open[0].Line.Id)
+	assert.Equal(t, "10", open[0].Ordered.String())
+	assert.Equal(t, "4", open[0].Received.String())
+	assert.Equal(t, "6", open[0].Open.String())
+
+	rest, err := ConvertPurchaseOrderToBill(purchaseOrder, OpenQuantities(open))
+	require.NoError(t, err)
+	require.Len(t, rest.Line, 1)
+	assert.Equal(t, json.Number("6"), rest.Line[0].ItemBasedExpenseLineDetail.Qty)
+
+	purchaseOrder.POStatus = ClosedPOStatus
+	open, err = client.FindOpenPurchaseOrderLines(params, "40")
+	require.NoError(t, err)
+	assert.Empty(t, open)
+}
@@ -0,0 +1,354 @@
+// Package qb builds statements of the QuickBooks Online query language.
+//
+// Values are escaped and formatted with quickbooks.QueryLiteral, so user
+// input can be passed to conditions as is:
+//
+//	query, err := qb.Select[quickbooks.Invoice]().
+//		Where(qb.Eq("CustomerRef", customerId), qb.Gt("MetaData.LastUpdatedTime", since)).
+//		OrderBy("TxnDate", qb.Desc).
+//		Limit(100).
+//		Build()
+//
+// A statement is a quickbooks.QueryStatement, so it can be passed to the
+// QueryXs functions as is, and an invalid one is reported as their error.
+// Query, All and BatchQuery take the statement too:
+//
+//	invoices, err := client.QueryInvoices(params, qb.Select[quickbooks.Invoice]().Where(qb.Gt("Balance", 0)))
+//	invoices, err = qb.All(client, params, qb.Select[quickbooks.Invoice]().Where(qb.Gt("Balance", 0)))
+//
+// The query language only supports AND between conditions, so Where joins
+// every condition with AND.
+package qb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tommyhedley/quickbooks-go"
+)
+
+// MaxResults is the largest page QuickBooks returns for a query.
+const MaxResults = 1000
+
+// Order is the direction of an ORDERBY clause.
+type Order string
+
+const (
+	Asc  Order = "ASC"
+	Desc Order = "DESC"
+)
+
+// Operators supported by the query language.
+const (
+	OpEq   = "="
+	OpLt   = "<"
+	OpGt   = ">"
+	OpLe   = "<="
+	OpGe   = ">="
+	OpIn   = "IN"
+	OpLike = "LIKE"
+)
+
+var fieldPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(\.[A-Za-z][A-Za-z0-9]*)*$`)
+
+// Condition is a single comparison of a WHERE clause.
+type Condition struct {
+	clause string
+	err    error
+}
+
+// Cond builds a condition from an operator given at run time. The operator
+// must be one of those supported by the query language.
+func Cond(field, op string, values ...interface{}) Condition {
+	switch op = strings.ToUpper(strings.TrimSpace(op)); op {
+	case OpEq, OpLt, OpGt, OpLe, OpGe:
+		if len(values) != 1 {
+			return Condition{err: fmt.Errorf("operator %s takes one value, got %d", op, len(values))}
+		}
+		return compare(field, op, values[0])
+	case OpIn:
+		return In(field, values...)
+	case OpLike:
+		if len(values) != 1 {
+			return Condition{err: fmt.Errorf("operator LIKE takes one value, got %d", len(values))}
+		}
+		pattern, ok := values[0].(string)
+		if !ok {
+			return Condition{err: fmt.Errorf("operator LIKE takes a string, got %T", values[0])}
+		}
+		return Like(field, pattern)
+	}
+	return Condition{err: fmt.Errorf("unsupported operator %q", op)}
+}
+
+// Eq matches field = value.
+func Eq(field string, value interface{}) Condition {
+	return compare(field, OpEq, value)
+}
+
+// Lt matches field < value.
+func Lt(field string, value interface{}) Condition {
+	return compare(field, OpLt, value)
+}
+
+// Gt matches field > value.
+func Gt(field string, value interface{}) Condition {
+	return compare(field, OpGt, value)
+}
+
+// Le matches field <= value.
+func Le(field string, value interface{}) Condition {
+	return compare(field, OpLe, value)
+}
+
+// Ge matches field >= value.
+func Ge(field string, value interface{}) Condition {
+	return compare(field, OpGe, value)
+}
+
+// In matches field IN (values...).
+func In(field string, values ...interface{}) Condition {
+	if err := checkField(field); err != nil {
+		return Condition{err: err}
+	}
+	if len(values) == 0 {
+		return Condition{err: fmt.Errorf("IN on %s needs at least one value", field)}
+	}
+
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literal, err := quickbooks.QueryLiteral(value)
+		if err != nil {
+			return Condition{err: fmt.Errorf("IN on %s: %v", field, err)}
+		}
+		literals[i] = literal
+	}
+
+	return Condition{clause: field + " IN (" + strings.Join(literals, ", ") + ")"}
+}
+
+// Like matches field LIKE pattern. The only wildcard QuickBooks supports is
+// %, which matches any run of characters and cannot be escaped.
+func Like(field, pattern string) Condition {
+	if err := checkField(field); err != nil {
+		return Condition{err: err}
+	}
+
+	literal, _ := quickbooks.QueryLiteral(pattern)
+
+	return Condition{clause: field + " LIKE " + literal}
+}
+
+// StartsWith matches fields beginning with prefix.
+func StartsWith(field, prefix string) Condition {
+	return likeText(field, prefix, "", "%")
+}
+
+// EndsWith matches fields ending with suffix.
+func EndsWith(field, suffix string) Condition {
+	return likeText(field, suffix, "%", "")
+}
+
+// Contains matches fields containing text.
+func Contains(field, text string) Condition {
+	return likeText(field, text, "%", "%")
+}
+
+func likeText(field, text, before, after string) Condition {
+	if strings.Contains(text, "%") {
+		return Condition{err: fmt.Errorf("LIKE on %s cannot match a literal %%", field)}
+	}
+	return Like(field, before+text+after)
+}
+
+func compare(field, op string, value interface{}) Condition {
+	if err := checkField(field); err != nil {
+		return Condition{err: err}
+	}
+
+	if _, ok := value.(bool); ok && op != OpEq {
+		return Condition{err: fmt.Errorf("operator %s cannot compare %s with a boolean", op, field)}
+	}
+
+	literal, err := quickbooks.QueryLiteral(value)
+	if err != nil {
+		return Condition{err: fmt.Errorf("%s %s: %v", field, op, err)}
+	}
+
+	return Condition{clause: field + " " + op + " " + literal}
+}
+
+func checkField(field string) error {
+	if !fieldPattern.MatchString(field) {
+		return fmt.Errorf("invalid field name %q", field)
+	}
+	return nil
+}
+
+type ordering struct {
+	field string
+	order Order
+}
+
+// SelectStatement is a query against the entity T. Its methods record the
+// first error they meet, which Build reports.
+type SelectStatement[T quickbooks.Entity] struct {
+	fields        []string
+	count         bool
+	conditions    []Condition
+	orderings     []ordering
+	startPosition int
+	maxResults    int
+	err           error
+}
+
+// Select starts a query returning the given fields of T, or every field
+// when none are given.
+func Select[T quickbooks.Entity](fields ...string) *SelectStatement[T] {
+	s := &SelectStatement[T]{}
+	for _, field := range fields {
+		s.setErr(checkField(field))
+	}
+	s.fields = fields
+	return s
+}
+
+// Count starts a query counting the entities of T.
+func Count[T quickbooks.Entity]() *SelectStatement[T] {
+	return &SelectStatement[T]{count: true}
+}
+
+// Where adds conditions, joined with AND.
+func (s *SelectStatement[T]) Where(conditions ...Condition) *SelectStatement[T] {
+	for _, condition := range conditions {
+		s.setErr(condition.err)
+	}
+	s.conditions = append(s.conditions, conditions...)
+	return s
+}
+
+// OrderBy adds a sort key.
+func (s *SelectStatement[T]) OrderBy(field string, order Order) *SelectStatement[T] {
+	s.setErr(checkField(field))
+	if order != Asc && order != Desc {
+		s.setErr(fmt.Errorf("invalid order %q", order))
+	}
+	s.orderings = append(s.orderings, ordering{field: field, order: order})
+	return s
+}
+
+// StartPosition sets the 1-based position of the first result.
+func (s *SelectStatement[T]) StartPosition(position int) *SelectStatement[T] {
+	if position < 1 {
+		s.setErr(fmt.Errorf("start position must be at least 1, got %d", position))
+	}
+	s.startPosition = position
+	return s
+}
+
+// Limit sets the maximum number of results, at most MaxResults.
+func (s *SelectStatement[T]) Limit(maxResults int) *SelectStatement[T] {
+	if maxResults < 1 || maxResults > MaxResults {
+		s.setErr(fmt.Errorf("limit must be between 1 and %d, got %d", MaxResults, maxResults))
+	}
+	s.maxResults = maxResults
+	return s
+}
+
+// Build returns the statement, or the first error met while building it.
+func (s *SelectStatement[T]) Build() (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	if s.count && len(s.orderings) > 0 {
+		return "", errors.New("a COUNT query cannot be ordered")
+	}
+
+	var b strings.Builder
+
+	b.WriteString("SELECT ")
+	switch {
+	case s.count:
+		b.WriteString("COUNT(*)")
+	case len(s.fields) > 0:
+		b.WriteString(strings.Join(s.fields, ", "))
+	default:
+		b.WriteString("*")
+	}
+	b.WriteString(" FROM ")
+	b.WriteString(quickbooks.EntityName[T]())
+
+	for i, condition := range s.conditions {
+		if i == 0 {
+			b.WriteString(" WHERE ")
+		} else {
+			b.WriteString(" AND ")
+		}
+		b.WriteString(condition.clause)
+	}
+
+	for i, o := range s.orderings {
+		if i == 0 {
+			b.WriteString(" ORDERBY ")
+		} else {
+			b.WriteString(", ")
+		}
+		b.WriteString(o.field + " " + string(o.order))
+	}
+
+	if s.startPosition > 0 {
+		b.WriteString(" STARTPOSITION " + strconv.Itoa(s.startPosition))
+	}
+	if s.maxResults > 0 {
+		b.WriteString(" MAXRESULTS " + strconv.Itoa(s.maxResults))
+	}
+
+	return b.String(), nil
+}
+
+// Query runs the statement with quickbooks.Query, paging through every
+// result. The statement must not set StartPosition or Limit. An invalid
+// statement yields the error from Build instead of running any query.
+func Query[T quickbooks.Entity](ctx context.Context, c *quickbooks.Client, params quickbooks.RequestParameters, s *SelectStatement[T]) iter.Seq2[T, error] {
+	query, err := s.Build()
+	if err != nil {
+		return func(yield func(T, error) bool) {
+			var zero T
+			yield(zero, err)
+		}
+	}
+	return quickbooks.Query[T](ctx, c, params, query)
+}
+
+// All collects every result of the statement, as Query does.
+func All[T quickbooks.Entity](c *quickbooks.Client, params quickbooks.RequestParameters, s *SelectStatement[T]) ([]T, error) {
+	var entities []T
+	for entity, err := range Query(params.Ctx, c, params, s) {
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
+
+// BatchQuery returns a batch item running the statement, or the error from
+// Build when the statement is invalid.
+func BatchQuery[T quickbooks.Entity](bid string, s *SelectStatement[T]) (quickbooks.BatchItemRequest, error) {
+	query, err := s.Build()
+	if err != nil {
+		return quickbooks.BatchItemRequest{}, err
+	}
+	return quickbooks.BatchQuery(bid, query), nil
+}
+
+func (s *SelectStatement[T]) setErr(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
@@ -0,0 +1,65 @@
+package qb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tommyhedley/quickbooks-go"
+)
+
+func TestSelectBuildsStatement(t *testing.T) {
+	since := time.Date(2024, 5, 2, 10, 11, 12, 0, time.FixedZone("PDT", -7*60*60))
+
+	query, err := Select[quickbooks.Invoice]().
+		Where(Eq("CustomerRef", "42"), Gt("MetaData.LastUpdatedTime", since), In("DocNumber", "1001", "1002")).
+		OrderBy("TxnDate", Desc).
+		StartPosition(11).
+		Limit(100).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM Invoice WHERE CustomerRef = '42' AND MetaData.LastUpdatedTime > '2024-05-02T10:11:12-07:00' AND DocNumber IN ('1001', '1002') ORDERBY TxnDate DESC STARTPOSITION 11 MAXRESULTS 100", query)
+
+	count, err := Count[quickbooks.Customer]().Where(Eq("Active", true)).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM Customer WHERE Active = true", count)
+}
+
+func TestValuesAreEscaped(t *testing.T) {
+	query, err := Select[quickbooks.Customer]("Id", "DisplayName").
+		Where(Eq("DisplayName", `Craig's \ Design`), StartsWith("CompanyName", "O'Brien")).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT Id, DisplayName FROM Customer WHERE DisplayName = 'Craig\'s \\ Design' AND CompanyName LIKE 'O\'Brien%'`, query)
+}
+
+func TestInvalidStatements(t *testing.T) {
+	tests := map[string]*SelectStatement[quickbooks.Customer]{
+		"field":       Select[quickbooks.Customer]().Where(Eq("Name; DROP", "x")),
+		"operator":    Select[quickbooks.Customer]().Where(Cond("Balance", "!=", 0)),
+		"wildcard":    Select[quickbooks.Customer]().Where(Contains("DisplayName", "100%")),
+		"empty in":    Select[quickbooks.Customer]().Where(In("Id")),
+		"value":       Select[quickbooks.Customer]().Where(Eq("Balance", struct{}{})),
+		"limit":       Select[quickbooks.Customer]().Limit(1001),
+		"start":       Select[quickbooks.Customer]().StartPosition(0),
+		"order":       Select[quickbooks.Customer]().OrderBy("Id", "UP"),
+		"count order": Count[quickbooks.Customer]().OrderBy("Id", Asc),
+	}
+
+	for name, statement := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := statement.Build()
+			assert.Error(t, err)
+
+			_, err = BatchQuery("1", statement)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCondNormalizesOperator(t *testing.T) {
+	query, err := Select[quickbooks.Item]().Where(Cond("Type", "in", "Service", "Inventory"), Cond("Name", "like", "Pump%")).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM Item WHERE Type IN ('Service', 'Inventory') AND Name LIKE 'Pump%'", query)
+}
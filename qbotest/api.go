@@ -0,0 +1,274 @@
+package qbotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxBatchItems and maxCDCResults mirror the limits of the real API.
+const (
+	maxBatchItems = 30
+	maxCDCResults = 1000
+)
+
+func (s *Server) serveBatch(w http.ResponseWriter, r *http.Request, rlm *realm) {
+	var payload struct {
+		BatchItemRequest []map[string]json.RawMessage
+	}
+	if err := decodeJSON(r.Body, &payload); err != nil {
+		writeFault(w, http.StatusBadRequest, "ValidationFault", "2500", "Invalid Request", err.Error())
+		return
+	}
+	if len(payload.BatchItemRequest) > maxBatchItems {
+		writeFault(w, http.StatusBadRequest, "ValidationFault", "1040", "Too many batch items", "Max 30 items allowed in a batch request")
+		return
+	}
+
+	now := s.opts.Now()
+	responses := make([]map[string]interface{}, 0, len(payload.BatchItemRequest))
+
+	for _, item := range payload.BatchItemRequest {
+		responses = append(responses, rlm.batchItem(item, now))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"BatchItemResponse": responses,
+		"time":              s.now(),
+	})
+}
+
+// batchItem runs a single batch item, returning its BatchItemResponse.
+func (r *realm) batchItem(item map[string]json.RawMessage, now time.Time) map[string]interface{} {
+	var bid, operation, options, query string
+	json.Unmarshal(item["bId"], &bid)
+	json.Unmarshal(item["operation"], &operation)
+	json.Unmarshal(item["optionsData"], &options)
+	json.Unmarshal(item["Query"], &query)
+
+	response := map[string]interface{}{"bId": bid}
+
+	if query != "" {
+		result, f := r.query(query)
+		if f != nil {
+			response["Fault"] = f.body()
+			return response
+		}
+		response["QueryResponse"] = result
+		return response
+	}
+
+	for key, raw := range item {
+		name, ok := entityName(key)
+		if !ok || name != key {
+			continue
+		}
+
+		var object map[string]interface{}
+		if err := decodeJSON(bytes.NewReader(raw), &object); err != nil {
+			response["Fault"] = invalid(err.Error()).body()
+			return response
+		}
+
+		if operation == "create" {
+			operation = ""
+		}
+		if options == "void" {
+			operation = "void"
+		}
+
+		result, f := r.write(name, operation, object, now)
+		if f != nil {
+			response["Fault"] = f.body()
+			return response
+		}
+		response[name] = result
+		return response
+	}
+
+	response["Fault"] = invalid("batch item holds neither a query nor an entity").body()
+	return response
+}
+
+func (s *Server) serveCDC(w http.ResponseWriter, r *http.Request, rlm *realm) {
+	since, err := time.Parse(time.RFC3339, r.URL.Query().Get("changedSince"))
+	if err != nil {
+		writeFault(w, http.StatusBadRequest, "ValidationFault", "2020", "Required param missing, need to supply the required value for the API", "Required parameter changedSince is missing or invalid")
+		return
+	}
+	if s.opts.Now().Sub(since) > 30*24*time.Hour {
+		writeFault(w, http.StatusBadRequest, "ValidationFault", "2040", "Invalid changedSince", "changedSince cannot be more than 30 days in the past")
+		return
+	}
+
+	type change struct {
+		object  map[string]interface{}
+		updated time.Time
+	}
+
+	var queryResponses []map[string]interface{}
+	total := 0
+
+	for _, resource := range strings.Split(r.URL.Query().Get("entities"), ",") {
+		name, ok := entityName(strings.TrimSpace(resource))
+		if !ok {
+			writeFault(w, http.StatusBadRequest, "ValidationFault", "2030", "Invalid entity", "Unsupported entity "+resource)
+			return
+		}
+
+		var changes []change
+		for _, object := range rlm.collection(name) {
+			if updated := lastUpdated(object); !updated.Before(since) {
+				changes = append(changes, change{object: object, updated: updated})
+			}
+		}
+		for _, t := range rlm.deleted {
+			if t.name == name && !t.deleted.Before(since) {
+				changes = append(changes, change{
+					object: map[string]interface{}{
+						"Id":       t.id,
+						"status":   "Deleted",
+						"domain":   "QBO",
+						"MetaData": map[string]interface{}{"LastUpdatedTime": t.deleted.Format(timeFormat)},
+					},
+					updated: t.deleted,
+				})
+			}
+		}
+		sort.SliceStable(changes, func(i, j int) bool { return changes[i].updated.Before(changes[j].updated) })
+
+		if room := maxCDCResults - total; len(changes) > room {
+			changes = changes[:room]
+		}
+		total += len(changes)
+
+		objects := make([]map[string]interface{}, len(changes))
+		for i, c := range changes {
+			objects[i] = c.object
+		}
+
+		queryResponse := map[string]interface{}{"startPosition": 1, "maxResults": len(objects)}
+		if len(objects) > 0 {
+			queryResponse[name] = objects
+		}
+		queryResponses = append(queryResponses, queryResponse)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"CDCResponse": []interface{}{map[string]interface{}{"QueryResponse": queryResponses}},
+		"time":        s.now(),
+	})
+}
+
+func (s *Server) serveUpload(w http.ResponseWriter, r *http.Request, rlm *realm) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		writeFault(w, http.StatusBadRequest, "ValidationFault", "2500", "Invalid Request", err.Error())
+		return
+	}
+
+	var metadata map[string]interface{}
+	var content []byte
+	var contentType, fileName string
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeFault(w, http.StatusBadRequest, "ValidationFault", "2500", "Invalid Request", err.Error())
+			return
+		}
+
+		switch {
+		case strings.HasPrefix(part.FormName(), "file_metadata_"):
+			if err := decodeJSON(part, &metadata); err != nil {
+				writeFault(w, http.StatusBadRequest, "ValidationFault", "2500", "Invalid Request", err.Error())
+				return
+			}
+		case strings.HasPrefix(part.FormName(), "file_content_"):
+			if content, err = io.ReadAll(part); err != nil {
+				writeFault(w, http.StatusBadRequest, "ValidationFault", "2500", "Invalid Request", err.Error())
+				return
+			}
+			contentType = part.Header.Get("Content-Type")
+			fileName = part.FileName()
+		}
+	}
+
+	if content == nil {
+		writeFault(w, http.StatusBadRequest, "ValidationFault", "2500", "Invalid Request", "missing file content")
+		return
+	}
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	if _, ok := metadata["FileName"]; !ok {
+		metadata["FileName"] = fileName
+	}
+	if _, ok := metadata["ContentType"]; !ok {
+		metadata["ContentType"] = contentType
+	}
+	metadata["Size"] = json.Number(strconv.Itoa(len(content)))
+	delete(metadata, "Id")
+
+	attachable, f := rlm.write("Attachable", "", metadata, s.opts.Now())
+	if f != nil {
+		f.write(w)
+		return
+	}
+	id := attachable["Id"].(string)
+	attachable["FileAccessUri"] = "/v3/company/" + rlm.id + "/download/" + id
+	attachable["TempDownloadUri"] = s.fileURL(rlm.id, id)
+	rlm.files[id] = content
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"AttachableResponse": []interface{}{map[string]interface{}{"Attachable": attachable}},
+		"time":               s.now(),
+	})
+}
+
+func (s *Server) serveDownload(w http.ResponseWriter, r *http.Request, rlm *realm, id string) {
+	if _, ok := rlm.files[id]; !ok {
+		notFound("Attachable", id).write(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	io.WriteString(w, s.fileURL(rlm.id, id))
+}
+
+func (s *Server) fileURL(realmId, id string) string {
+	return s.URL + filesPath + realmId + "/" + id
+}
+
+// serveFile serves uploaded content at the URLs returned by download, which
+// like the real temporary URLs need no authorization.
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, filesPath), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	rlm, ok := s.realms[parts[0]]
+	var content []byte
+	if ok {
+		content, ok = rlm.files[parts[1]]
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Write(content)
+}
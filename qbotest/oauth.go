@@ -0,0 +1,166 @@
+package qbotest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tommyhedley/quickbooks-go"
+)
+
+// issuedToken is an access or refresh token handed out by the server.
+type issuedToken struct {
+	realmId string
+	expiry  time.Time
+}
+
+// Token issues a fresh access and refresh token for the realm.
+func (s *Server) Token(realmId string) *quickbooks.BearerToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issue(realmId)
+}
+
+// AuthorizationCode returns a code that the token endpoint exchanges for a
+// token of the realm, as if the user had just connected the app.
+func (s *Server) AuthorizationCode(realmId string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := "code-" + randomString()
+	s.codes[code] = realmId
+	return code
+}
+
+// ExpireTokens makes every access token issued so far invalid, as happens
+// an hour after they are issued.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = make(map[string]issuedToken)
+}
+
+func (s *Server) issue(realmId string) *quickbooks.BearerToken {
+	now := s.opts.Now()
+	access, refresh := "access-"+randomString(), "refresh-"+randomString()
+
+	s.tokens[access] = issuedToken{realmId: realmId, expiry: now.Add(s.opts.AccessTokenTTL)}
+	s.refresh[refresh] = issuedToken{realmId: realmId, expiry: now.Add(s.opts.RefreshTokenTTL)}
+
+	token := &quickbooks.BearerToken{
+		AccessToken:            access,
+		RefreshToken:           refresh,
+		TokenType:              "bearer",
+		ExpiresIn:              seconds(s.opts.AccessTokenTTL),
+		XRefreshTokenExpiresIn: seconds(s.opts.RefreshTokenTTL),
+	}
+	token.SetExpiry(now)
+
+	return token
+}
+
+// authorized reports whether the request carries a live access token for
+// the realm.
+func (s *Server) authorized(r *http.Request, realmId string) bool {
+	access, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[access]
+	return ok && token.realmId == realmId && s.opts.Now().Before(token.expiry)
+}
+
+func (s *Server) checkClient(w http.ResponseWriter, r *http.Request) bool {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != s.opts.ClientId || secret != s.opts.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return false
+	}
+	return true
+}
+
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !s.checkClient(w, r) {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var realmId string
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		code := r.PostForm.Get("code")
+		id, ok := s.codes[code]
+		if !ok || r.PostForm.Get("redirect_uri") == "" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		delete(s.codes, code)
+		realmId = id
+
+	case "refresh_token":
+		refresh := r.PostForm.Get("refresh_token")
+		token, ok := s.refresh[refresh]
+		if !ok || !s.opts.Now().Before(token.expiry) {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		delete(s.refresh, refresh)
+		realmId = token.realmId
+
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.issue(realmId))
+}
+
+func (s *Server) serveRevoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !s.checkClient(w, r) {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token := r.PostForm.Get("token")
+	if issued, ok := s.refresh[token]; ok {
+		delete(s.refresh, token)
+		for access, t := range s.tokens {
+			if t.realmId == issued.realmId {
+				delete(s.tokens, access)
+			}
+		}
+	}
+	delete(s.tokens, token)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func seconds(d time.Duration) json.Number {
+	return json.Number(strconv.Itoa(int(d.Seconds())))
+}
+
+func randomString() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
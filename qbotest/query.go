@@ -0,0 +1,565 @@
+package qbotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// statement is a parsed query. The fake understands
+//
+//	SELECT * | COUNT(*) | field, ... FROM Entity
+//	[WHERE field op value [AND ...]]
+//	[ORDERBY field [ASC|DESC], ...]
+//	[STARTPOSITION n] [MAXRESULTS n]
+//
+// with the operators =, <, >, <=, >=, IN and LIKE.
+type statement struct {
+	entity        string
+	count         bool
+	fields        []string
+	conditions    []condition
+	orderBy       []orderKey
+	startPosition int
+	maxResults    int
+}
+
+type condition struct {
+	field  string
+	op     string
+	values []interface{}
+}
+
+type orderKey struct {
+	field string
+	desc  bool
+}
+
+func (s *Server) serveQuery(w http.ResponseWriter, r *http.Request, rlm *realm) {
+	query := r.URL.Query().Get("query")
+	if query == "" && r.Method == http.MethodPost {
+		body, _ := io.ReadAll(r.Body)
+		query = string(body)
+	}
+
+	result, f := rlm.query(query)
+	if f != nil {
+		f.write(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"QueryResponse": result,
+		"time":          s.now(),
+	})
+}
+
+// query runs a query, returning the QueryResponse object.
+func (r *realm) query(query string) (map[string]interface{}, *fault) {
+	stmt, err := parseQuery(query)
+	if err != nil {
+		return nil, &fault{status: http.StatusBadRequest, kind: "ValidationFault", code: "4000", message: "Error parsing query", detail: "QueryParserError: " + err.Error()}
+	}
+
+	var matches []map[string]interface{}
+	if stmt.entity == "CompanyInfo" {
+		matches = append(matches, r.companyInfo())
+	}
+	for _, object := range r.collection(stmt.entity) {
+		if stmt.entity != "CompanyInfo" && stmt.matches(object) {
+			matches = append(matches, object)
+		}
+	}
+
+	if stmt.count {
+		return map[string]interface{}{"totalCount": len(matches)}, nil
+	}
+
+	stmt.sort(matches)
+
+	start := stmt.startPosition - 1
+	if start > len(matches) {
+		start = len(matches)
+	}
+	end := start + stmt.maxResults
+	if end > len(matches) {
+		end = len(matches)
+	}
+	page := matches[start:end]
+
+	result := map[string]interface{}{}
+	if len(page) == 0 {
+		return result, nil
+	}
+
+	objects := make([]map[string]interface{}, len(page))
+	for i, object := range page {
+		objects[i] = stmt.project(object)
+	}
+
+	result[stmt.entity] = objects
+	result["startPosition"] = stmt.startPosition
+	result["maxResults"] = len(objects)
+
+	return result, nil
+}
+
+func (s *statement) matches(object map[string]interface{}) bool {
+	for _, c := range s.conditions {
+		if !c.matches(lookup(object, c.field)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c condition) matches(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+
+	switch c.op {
+	case "IN":
+		for _, v := range c.values {
+			if compare(value, v) == 0 {
+				return true
+			}
+		}
+		return false
+	case "LIKE":
+		return like(fmt.Sprint(value), fmt.Sprint(c.values[0]))
+	}
+
+	n := compare(value, c.values[0])
+	switch c.op {
+	case "=":
+		return n == 0
+	case "<":
+		return n < 0
+	case ">":
+		return n > 0
+	case "<=":
+		return n <= 0
+	case ">=":
+		return n >= 0
+	}
+	return false
+}
+
+func (s *statement) sort(objects []map[string]interface{}) {
+	keys := s.orderBy
+	if len(keys) == 0 {
+		keys = []orderKey{{field: "Id"}}
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		for _, key := range keys {
+			n := compare(lookup(objects[i], key.field), lookup(objects[j], key.field))
+			if n == 0 {
+				continue
+			}
+			return (n < 0) != key.desc
+		}
+		return false
+	})
+}
+
+// project keeps the selected fields, along with the Id and SyncToken
+// QuickBooks always returns.
+func (s *statement) project(object map[string]interface{}) map[string]interface{} {
+	if len(s.fields) == 0 {
+		return object
+	}
+
+	projected := map[string]interface{}{"Id": object["Id"], "SyncToken": object["SyncToken"], "domain": object["domain"]}
+	for _, field := range s.fields {
+		top := strings.SplitN(field, ".", 2)[0]
+		if value, ok := object[top]; ok {
+			projected[top] = value
+		}
+	}
+	return projected
+}
+
+// lookup resolves a dotted field path. References such as CustomerRef
+// compare by their value.
+func lookup(object map[string]interface{}, field string) interface{} {
+	var value interface{} = object
+	for _, part := range strings.Split(field, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if value, ok = lookupKey(m, part); !ok {
+			return nil
+		}
+	}
+
+	if ref, ok := value.(map[string]interface{}); ok {
+		if v, ok := ref["value"]; ok {
+			return v
+		}
+	}
+	return value
+}
+
+func lookupKey(m map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// compare orders two values as numbers, times, booleans or strings.
+func compare(a, b interface{}) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		}
+		return 1
+	}
+
+	as, bs := fmt.Sprint(a), fmt.Sprint(b)
+
+	if af, err := strconv.ParseFloat(as, 64); err == nil {
+		if bf, err := strconv.ParseFloat(bs, 64); err == nil {
+			switch {
+			case af < bf:
+				return -1
+			case af > bf:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	if at, ok := parseTime(as); ok {
+		if bt, ok := parseTime(bs); ok {
+			return at.Compare(bt)
+		}
+	}
+
+	return strings.Compare(strings.ToLower(as), strings.ToLower(bs))
+}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// like matches s against a pattern in which % matches any run of
+// characters, ignoring case like QuickBooks does.
+func like(s, pattern string) bool {
+	s, pattern = strings.ToLower(s), strings.ToLower(pattern)
+	parts := strings.Split(pattern, "%")
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 {
+			return strings.HasSuffix(s, part)
+		}
+		idx := strings.Index(s, part)
+		if idx < 0 {
+			return false
+		}
+		s = s[idx+len(part):]
+	}
+
+	return s == ""
+}
+
+// parseQuery parses the subset of the query language the fake supports.
+func parseQuery(query string) (*statement, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	stmt := &statement{startPosition: 1, maxResults: 100}
+
+	if !p.keyword("SELECT") {
+		return nil, fmt.Errorf("expected SELECT")
+	}
+
+	switch {
+	case p.peek().text == "*":
+		p.next()
+	case p.keyword("COUNT"):
+		if p.next().text != "(" || p.next().text != "*" || p.next().text != ")" {
+			return nil, fmt.Errorf("expected COUNT(*)")
+		}
+		stmt.count = true
+	default:
+		for {
+			field := p.next()
+			if field.kind != identToken {
+				return nil, fmt.Errorf("expected a field, got %q", field.text)
+			}
+			stmt.fields = append(stmt.fields, field.text)
+			if p.peek().text != "," {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if !p.keyword("FROM") {
+		return nil, fmt.Errorf("expected FROM")
+	}
+	entity := p.next()
+	name, ok := entityName(entity.text)
+	if !ok {
+		return nil, fmt.Errorf("unknown entity %q", entity.text)
+	}
+	stmt.entity = name
+
+	if p.keyword("WHERE") {
+		for {
+			c, err := p.condition()
+			if err != nil {
+				return nil, err
+			}
+			stmt.conditions = append(stmt.conditions, c)
+			if !p.keyword("AND") {
+				break
+			}
+		}
+	}
+
+	if p.keyword("ORDERBY") || (p.keyword("ORDER") && p.keyword("BY")) {
+		for {
+			field := p.next()
+			if field.kind != identToken {
+				return nil, fmt.Errorf("expected a field to order by, got %q", field.text)
+			}
+			key := orderKey{field: field.text}
+			if p.keyword("DESC") {
+				key.desc = true
+			} else {
+				p.keyword("ASC")
+			}
+			stmt.orderBy = append(stmt.orderBy, key)
+			if p.peek().text != "," {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if p.keyword("STARTPOSITION") {
+		n, err := p.number()
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid STARTPOSITION")
+		}
+		stmt.startPosition = n
+	}
+
+	if p.keyword("MAXRESULTS") {
+		n, err := p.number()
+		if err != nil || n < 1 || n > 1000 {
+			return nil, fmt.Errorf("invalid MAXRESULTS")
+		}
+		stmt.maxResults = n
+	}
+
+	if t := p.next(); t.kind != endToken {
+		return nil, fmt.Errorf("unexpected %q", t.text)
+	}
+
+	return stmt, nil
+}
+
+type tokenKind int
+
+const (
+	endToken tokenKind = iota
+	identToken
+	stringToken
+	numberToken
+	symbolToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'':
+			var b strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '\''; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			i++
+			tokens = append(tokens, token{kind: stringToken, text: b.String()})
+		case unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: identToken, text: string(runes[start:i])})
+		case unicode.IsDigit(r) || r == '-':
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: numberToken, text: string(runes[start:i])})
+		case r == '<' || r == '>':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{kind: symbolToken, text: string(runes[i : i+2])})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, token{kind: symbolToken, text: string(r)})
+			i++
+		case strings.ContainsRune("=*(),", r):
+			tokens = append(tokens, token{kind: symbolToken, text: string(r)})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{kind: endToken}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return t
+}
+
+// keyword consumes the next token if it is the given keyword.
+func (p *parser) keyword(word string) bool {
+	if t := p.peek(); t.kind == identToken && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) number() (int, error) {
+	t := p.next()
+	if t.kind != numberToken {
+		return 0, fmt.Errorf("expected a number, got %q", t.text)
+	}
+	return strconv.Atoi(t.text)
+}
+
+var comparisons = map[string]bool{"=": true, "<": true, ">": true, "<=": true, ">=": true}
+
+func (p *parser) condition() (condition, error) {
+	field := p.next()
+	if field.kind != identToken {
+		return condition{}, fmt.Errorf("expected a field, got %q", field.text)
+	}
+	c := condition{field: field.text}
+
+	switch op := p.next(); {
+	case op.kind == symbolToken && comparisons[op.text]:
+		c.op = op.text
+	case op.kind == identToken && (strings.EqualFold(op.text, "IN") || strings.EqualFold(op.text, "LIKE")):
+		c.op = strings.ToUpper(op.text)
+	default:
+		return condition{}, fmt.Errorf("unsupported operator %q", op.text)
+	}
+
+	if c.op == "IN" {
+		if p.next().text != "(" {
+			return condition{}, fmt.Errorf("expected ( after IN")
+		}
+		for {
+			value, err := p.value()
+			if err != nil {
+				return condition{}, err
+			}
+			c.values = append(c.values, value)
+			if t := p.next(); t.text == ")" {
+				break
+			} else if t.text != "," {
+				return condition{}, fmt.Errorf("expected , or ) in IN list")
+			}
+		}
+		return c, nil
+	}
+
+	value, err := p.value()
+	if err != nil {
+		return condition{}, err
+	}
+	if c.op == "LIKE" {
+		if _, ok := value.(string); !ok {
+			return condition{}, fmt.Errorf("LIKE needs a string")
+		}
+	}
+	c.values = []interface{}{value}
+
+	return c, nil
+}
+
+func (p *parser) value() (interface{}, error) {
+	switch t := p.next(); {
+	case t.kind == stringToken:
+		return t.text, nil
+	case t.kind == numberToken:
+		return json.Number(t.text), nil
+	case t.kind == identToken && strings.EqualFold(t.text, "true"):
+		return true, nil
+	case t.kind == identToken && strings.EqualFold(t.text, "false"):
+		return false, nil
+	default:
+		return nil, fmt.Errorf("expected a value, got %q", t.text)
+	}
+}
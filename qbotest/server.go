@@ -0,0 +1,361 @@
+// Package qbotest runs an in-memory fake of the QuickBooks Online API for
+// tests.
+//
+// A Server answers the accounting API under /v3/company/{realm}/ as well as
+// the OpenID discovery, token and revocation endpoints, so a
+// quickbooks.Client can be exercised end to end without a sandbox:
+//
+//	server := qbotest.NewServer(qbotest.Options{})
+//	defer server.Close()
+//
+//	client, err := quickbooks.NewClient(server.ClientRequest())
+//	params := quickbooks.RequestParameters{
+//		Ctx:     ctx,
+//		RealmId: "1234",
+//		Token:   server.Token("1234"),
+//	}
+//
+// Entities are kept as JSON objects, so every entity known to the
+// quickbooks package can be created, read, updated, deleted, voided,
+// queried, batched and followed through change data capture.
+package qbotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tommyhedley/quickbooks-go"
+)
+
+// DiscoveryPath is the path of the OpenID discovery document.
+const DiscoveryPath = "/.well-known/openid_configuration"
+
+const (
+	tokenPath      = "/oauth2/v1/tokens/bearer"
+	revocationPath = "/oauth2/v1/tokens/revoke"
+	filesPath      = "/files/"
+	apiPath        = "/v3/company/"
+)
+
+// Options configures a Server.
+type Options struct {
+	// ClientId and ClientSecret are the credentials the token endpoints
+	// accept. They default to "client-id" and "client-secret".
+	ClientId     string
+	ClientSecret string
+	// AccessTokenTTL and RefreshTokenTTL are the lifetimes of issued
+	// tokens. They default to an hour and 100 days.
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// Now is the server's clock. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Hook is run before the server handles a request. Returning true means the
+// hook wrote the response and the request is not handled further.
+type Hook func(w http.ResponseWriter, r *http.Request) bool
+
+// Server is a fake QuickBooks Online API.
+type Server struct {
+	*httptest.Server
+
+	opts Options
+
+	mu      sync.Mutex
+	realms  map[string]*realm
+	tokens  map[string]issuedToken
+	refresh map[string]issuedToken
+	codes   map[string]string
+	hooks   []Hook
+	latency time.Duration
+}
+
+// NewServer starts a Server. Close it when done.
+func NewServer(opts Options) *Server {
+	if opts.ClientId == "" {
+		opts.ClientId = "client-id"
+	}
+	if opts.ClientSecret == "" {
+		opts.ClientSecret = "client-secret"
+	}
+	if opts.AccessTokenTTL == 0 {
+		opts.AccessTokenTTL = time.Hour
+	}
+	if opts.RefreshTokenTTL == 0 {
+		opts.RefreshTokenTTL = 100 * 24 * time.Hour
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	s := &Server{
+		opts:    opts,
+		realms:  make(map[string]*realm),
+		tokens:  make(map[string]issuedToken),
+		refresh: make(map[string]issuedToken),
+		codes:   make(map[string]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// ClientRequest returns a ClientRequest for a quickbooks.Client talking to
+// the server.
+func (s *Server) ClientRequest() quickbooks.ClientRequest {
+	return quickbooks.ClientRequest{
+		Client:       s.Client(),
+		DiscoveryAPI: s.DiscoveryAPI(),
+		ClientId:     s.opts.ClientId,
+		ClientSecret: s.opts.ClientSecret,
+		Endpoint:     s.URL,
+	}
+}
+
+// DiscoveryAPI returns the endpoints the server serves.
+func (s *Server) DiscoveryAPI() *quickbooks.DiscoveryAPI {
+	return &quickbooks.DiscoveryAPI{
+		Issuer:                s.URL,
+		AuthorizationEndpoint: s.URL + "/connect/oauth2",
+		TokenEndpoint:         s.URL + tokenPath,
+		UserinfoEndpoint:      s.URL + "/v1/openid_connect/userinfo",
+		RevocationEndpoint:    s.URL + revocationPath,
+		JwksUri:               s.URL + "/v1/openid_connect/keys",
+	}
+}
+
+// AddHook adds a hook run before every request, in the order added.
+func (s *Server) AddHook(hook Hook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, hook)
+}
+
+// FailNext answers the next n API requests with the given status and a
+// fault body.
+func (s *Server) FailNext(n int, status int) {
+	s.AddHook(s.countdown(n, func(w http.ResponseWriter) {
+		writeFault(w, status, "SystemFault", "10000", "An application error has occurred while processing your request", "")
+	}))
+}
+
+// RateLimitNext answers the next n API requests with a 429, sending
+// retryAfter in the Retry-After header when it is not zero.
+func (s *Server) RateLimitNext(n int, retryAfter time.Duration) {
+	s.AddHook(s.countdown(n, func(w http.ResponseWriter) {
+		if retryAfter > 0 {
+			w.Header().Set("Retry-After", fmt.Sprint(int(retryAfter.Seconds())))
+		}
+		writeFault(w, http.StatusTooManyRequests, "ThrottleExceeded", "003001", "ThrottleExceeded", "The request exceeded the maximum number of requests allowed")
+	}))
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+func (s *Server) countdown(n int, respond func(w http.ResponseWriter)) Hook {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) bool {
+		if !strings.HasPrefix(r.URL.Path, apiPath) {
+			return false
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if n <= 0 {
+			return false
+		}
+		n--
+		respond(w)
+		return true
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	hooks := append([]Hook(nil), s.hooks...)
+	latency := s.latency
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for _, hook := range hooks {
+		if hook(w, r) {
+			return
+		}
+	}
+
+	switch path := r.URL.Path; {
+	case path == DiscoveryPath:
+		writeJSON(w, http.StatusOK, s.DiscoveryAPI())
+	case path == tokenPath:
+		s.serveToken(w, r)
+	case path == revocationPath:
+		s.serveRevoke(w, r)
+	case strings.HasPrefix(path, filesPath):
+		s.serveFile(w, r)
+	case strings.HasPrefix(path, apiPath):
+		s.serveAPI(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveAPI(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, apiPath), "/")
+	if len(parts) < 2 {
+		http.NotFound(w, r)
+		return
+	}
+	realmId, resource, rest := parts[0], parts[1], parts[2:]
+
+	if !s.authorized(r, realmId) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="Intuit", error="invalid_token"`)
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"fault": map[string]interface{}{
+				"error": []map[string]string{{
+					"message": "message=AuthenticationFailed; errorCode=003200; statusCode=401",
+					"detail":  "Token expired or invalid",
+					"code":    "3200",
+				}},
+				"type": "AUTHENTICATION",
+			},
+		})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rlm := s.realm(realmId)
+
+	switch {
+	case strings.EqualFold(resource, "query"):
+		s.serveQuery(w, r, rlm)
+	case resource == "batch" && r.Method == http.MethodPost:
+		s.serveBatch(w, r, rlm)
+	case resource == "cdc" && r.Method == http.MethodGet:
+		s.serveCDC(w, r, rlm)
+	case resource == "upload" && r.Method == http.MethodPost:
+		s.serveUpload(w, r, rlm)
+	case resource == "download" && r.Method == http.MethodGet && len(rest) == 1:
+		s.serveDownload(w, r, rlm, rest[0])
+	default:
+		s.serveEntity(w, r, rlm, resource, rest)
+	}
+}
+
+func (s *Server) serveEntity(w http.ResponseWriter, r *http.Request, rlm *realm, resource string, rest []string) {
+	name, ok := entityName(resource)
+	if !ok {
+		writeFault(w, http.StatusBadRequest, "ValidationFault", "2010", "Unsupported Operation", "Operation "+resource+" is not supported.")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && len(rest) == 1:
+		object, fault := rlm.read(name, rest[0])
+		if fault != nil {
+			fault.write(w)
+			return
+		}
+		s.writeEntity(w, name, object)
+
+	case r.Method == http.MethodPost && len(rest) == 0:
+		var object map[string]interface{}
+		if err := decodeJSON(r.Body, &object); err != nil {
+			writeFault(w, http.StatusBadRequest, "ValidationFault", "2500", "Invalid Request", err.Error())
+			return
+		}
+
+		result, fault := rlm.write(name, r.URL.Query().Get("operation"), object, s.opts.Now())
+		if fault != nil {
+			fault.write(w)
+			return
+		}
+		s.writeEntity(w, name, result)
+
+	default:
+		writeFault(w, http.StatusMethodNotAllowed, "ValidationFault", "2010", "Unsupported Operation", r.Method+" "+r.URL.Path)
+	}
+}
+
+func (s *Server) writeEntity(w http.ResponseWriter, name string, object map[string]interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		name:   object,
+		"time": s.now(),
+	})
+}
+
+func (s *Server) realm(realmId string) *realm {
+	rlm, ok := s.realms[realmId]
+	if !ok {
+		rlm = newRealm(realmId)
+		s.realms[realmId] = rlm
+	}
+	return rlm
+}
+
+func (s *Server) now() string {
+	return s.opts.Now().Format(timeFormat)
+}
+
+// Put stores entity, a quickbooks entity or a pointer to one, in the realm
+// as is, assigning an Id and SyncToken when they are missing. It returns
+// the entity's Id.
+func (s *Server) Put(realmId string, entity interface{}) (string, error) {
+	name, object, err := toObject(entity)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.realm(realmId).put(name, object, s.opts.Now()), nil
+}
+
+// Get decodes the stored entity with the given Id into entity, a pointer to
+// a quickbooks entity. It reports whether the entity exists.
+func (s *Server) Get(realmId, id string, entity interface{}) (bool, error) {
+	name, _, err := toObject(entity)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	object, fault := s.realm(realmId).read(name, id)
+	var data []byte
+	if fault == nil {
+		data, err = json.Marshal(object)
+	}
+	s.mu.Unlock()
+
+	if fault != nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, json.Unmarshal(data, entity)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,234 @@
+package qbotest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tommyhedley/quickbooks-go"
+	"github.com/tommyhedley/quickbooks-go/qb"
+	"github.com/tommyhedley/quickbooks-go/qbotest"
+)
+
+const realmId = "4620816365"
+
+func newClient(t *testing.T, policy *quickbooks.RetryPolicy) (*qbotest.Server, *quickbooks.Client, quickbooks.RequestParameters) {
+	server := qbotest.NewServer(qbotest.Options{})
+	t.Cleanup(server.Close)
+
+	req := server.ClientRequest()
+	req.RetryPolicy = policy
+	client, err := quickbooks.NewClient(req)
+	require.NoError(t, err)
+
+	params := quickbooks.RequestParameters{
+		Ctx:             context.Background(),
+		WaitOnRateLimit: true,
+		RealmId:         realmId,
+		Token:           server.Token(realmId),
+	}
+
+	return server, client, params
+}
+
+func TestCustomerLifecycle(t *testing.T) {
+	server, client, params := newClient(t, nil)
+
+	customer, err := client.CreateCustomer(params, &quickbooks.Customer{DisplayName: "Craig's Design", CompanyName: "Craig's Design and Landscaping"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, customer.Id)
+	assert.Equal(t, "0", customer.SyncToken)
+
+	customer, err = client.SparseUpdateCustomer(params, &quickbooks.Customer{Id: customer.Id, Notes: "Pays late"})
+	require.NoError(t, err)
+	assert.Equal(t, "1", customer.SyncToken)
+	assert.Equal(t, "Craig's Design and Landscaping", customer.CompanyName)
+	assert.Equal(t, "Pays late", customer.Notes)
+
+	found, err := client.FindCustomerByName(params, "Craig's Design")
+	require.NoError(t, err)
+	assert.Equal(t, customer.Id, found.Id)
+
+	customers, err := client.QueryCustomers(params, qb.Select[quickbooks.Customer]().Where(qb.Eq("DisplayName", "Craig's Design")))
+	require.NoError(t, err)
+	require.Len(t, customers, 1)
+	assert.Equal(t, "Craig's Design", customers[0].DisplayName)
+
+	var stored quickbooks.Customer
+	ok, err := server.Get(realmId, customer.Id, &stored)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "Pays late", stored.Notes)
+
+	_, err = client.FindCustomerById(params, "999")
+	assert.ErrorIs(t, err, quickbooks.ErrObjectNotFound)
+}
+
+func TestStaleSyncToken(t *testing.T) {
+	_, client, params := newClient(t, nil)
+
+	invoice, err := client.CreateInvoice(params, &quickbooks.Invoice{DocNumber: "1001", CustomerRef: quickbooks.ReferenceType{Value: "1"}})
+	require.NoError(t, err)
+
+	stale := *invoice
+	_, err = client.SparseUpdateInvoice(params, &quickbooks.Invoice{Id: invoice.Id, PrivateNote: "first"})
+	require.NoError(t, err)
+
+	err = client.DeleteInvoice(params, &stale)
+	assert.ErrorIs(t, err, quickbooks.ErrStaleObject)
+}
+
+func TestQueryLanguage(t *testing.T) {
+	server, client, params := newClient(t, nil)
+
+	for _, name := range []string{"Hours", "Design", "Installation", "Pump", "Rock Fountain"} {
+		_, err := server.Put(realmId, &quickbooks.Item{Name: name, Type: "Service", Active: true})
+		require.NoError(t, err)
+	}
+
+	query := qb.Select[quickbooks.Item]().
+		Where(qb.In("Name", "Pump", "Design", "Hours"), qb.Eq("Active", true)).
+		OrderBy("Name", qb.Desc).
+		Limit(2)
+
+	items, err := client.QueryItems(params, query)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Pump", items[0].Name)
+	assert.Equal(t, "Hours", items[1].Name)
+
+	items, err = qb.All(client, params, qb.Select[quickbooks.Item]().Where(qb.StartsWith("Name", "rock")))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	_, err = qb.All(client, params, qb.Select[quickbooks.Item]().Where(qb.Cond("Name", "!=", "Pump")))
+	assert.Error(t, err)
+
+	all, err := client.FindItems(params)
+	require.NoError(t, err)
+	assert.Len(t, all, 5)
+
+	_, err = client.QueryItems(params, quickbooks.RawQuery("SELECT * FROM Item WHERE Name != 'Pump'"))
+	assert.Error(t, err)
+}
+
+func TestBatchAndChangeFeed(t *testing.T) {
+	_, client, params := newClient(t, nil)
+	since := time.Now().Add(-time.Minute)
+
+	vendor, err := client.CreateVendor(params, &quickbooks.Vendor{DisplayName: "Books by Bessie"})
+	require.NoError(t, err)
+
+	result, err := client.ExecuteBatch(params, []quickbooks.BatchItemRequest{
+		quickbooks.BatchCreate("1", &quickbooks.Customer{DisplayName: "Amy's Bird Sanctuary"}),
+		quickbooks.BatchDelete("2", vendor),
+		quickbooks.BatchDelete("3", &quickbooks.Vendor{Id: "404", SyncToken: "0"}),
+		quickbooks.BatchQuery("4", "SELECT COUNT(*) FROM Vendor"),
+	})
+	require.NoError(t, err)
+
+	item, _ := result.Get("1")
+	customer, ok := quickbooks.BatchEntity[quickbooks.Customer](item)
+	require.True(t, ok)
+	assert.Equal(t, "Amy's Bird Sanctuary", customer.DisplayName)
+
+	failed := result.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "3", failed[0].BID)
+	assert.ErrorIs(t, failed[0].Err, quickbooks.ErrObjectNotFound)
+
+	count, _ := result.Get("4")
+	assert.Equal(t, 0, count.QueryResponse.TotalCount)
+
+	feed, err := client.ChangeFeed(params, []string{"Customer", "Vendor"}, since)
+	require.NoError(t, err)
+	require.Len(t, feed.Changes, 2)
+	ops := map[string]quickbooks.ChangeOp{}
+	for _, change := range feed.Changes {
+		ops[change.Entity] = change.Op
+	}
+	assert.Equal(t, map[string]quickbooks.ChangeOp{"Customer": quickbooks.ChangeUpsert, "Vendor": quickbooks.ChangeDelete}, ops)
+}
+
+func TestUploadAndDownload(t *testing.T) {
+	_, client, params := newClient(t, nil)
+
+	attachable, err := client.UploadAttachableWithParams(params, &quickbooks.Attachable{FileName: "receipt.txt", ContentType: quickbooks.TXT}, strings.NewReader("paid in full"))
+	require.NoError(t, err)
+	assert.Equal(t, "12", attachable.Size.String())
+
+	downloadURL, err := client.GetAttachableDownloadURL(params, attachable.Id)
+	require.NoError(t, err)
+
+	resp, err := http.Get(downloadURL.String())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "paid in full", string(content))
+}
+
+func TestOAuthEndToEnd(t *testing.T) {
+	server, _, _ := newClient(t, nil)
+
+	discovery, err := quickbooks.FetchDiscoveryAPI(context.Background(), server.Client(), server.URL+qbotest.DiscoveryPath)
+	require.NoError(t, err)
+	assert.Equal(t, server.DiscoveryAPI().TokenEndpoint, discovery.TokenEndpoint)
+
+	req := server.ClientRequest()
+	req.DiscoveryAPI = discovery
+	client, err := quickbooks.NewClient(req)
+	require.NoError(t, err)
+
+	token, err := client.RetrieveBearerToken(server.AuthorizationCode(realmId), "https://example.com/callback")
+	require.NoError(t, err)
+
+	params := quickbooks.RequestParameters{Ctx: context.Background(), WaitOnRateLimit: true, RealmId: realmId, Token: token}
+	_, err = client.FindCompanyInfo(params)
+	require.NoError(t, err)
+
+	server.ExpireTokens()
+	_, err = client.FindCompanyInfo(params)
+	require.ErrorIs(t, err, quickbooks.ErrUnauthorized)
+
+	refreshed, err := client.RefreshToken(token.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, token.RefreshToken, refreshed.RefreshToken)
+
+	params.Token = refreshed
+	info, err := client.FindCompanyInfo(params)
+	require.NoError(t, err)
+	assert.Equal(t, realmId, info.Id)
+
+	_, err = client.RefreshToken(token.RefreshToken)
+	assert.Error(t, err)
+}
+
+func TestFaultInjection(t *testing.T) {
+	server, client, params := newClient(t, &quickbooks.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+
+	server.FailNext(2, http.StatusServiceUnavailable)
+	_, err := client.FindCompanyInfo(params)
+	require.NoError(t, err)
+
+	server.RateLimitNext(3, 0)
+	_, err = client.FindCompanyInfo(params)
+	var rateLimit *quickbooks.RateLimitError
+	require.ErrorAs(t, err, &rateLimit)
+
+	server.SetLatency(50 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	params.Ctx = ctx
+	_, err = client.FindCompanyInfo(params)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,385 @@
+package qbotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tommyhedley/quickbooks-go"
+)
+
+// timeFormat is the layout of the timestamps QuickBooks returns.
+const timeFormat = "2006-01-02T15:04:05.000-07:00"
+
+// dateFields are the date-only fields, which QuickBooks stores as
+// YYYY-MM-DD.
+var dateFields = map[string]bool{
+	"AcceptedDate":    true,
+	"BirthDate":       true,
+	"DueDate":         true,
+	"ExpirationDate":  true,
+	"HiredDate":       true,
+	"InvStartDate":    true,
+	"OpenBalanceDate": true,
+	"ReleasedDate":    true,
+	"ServiceDate":     true,
+	"ShipDate":        true,
+	"TxnDate":         true,
+}
+
+// fault is an error response.
+type fault struct {
+	status  int
+	kind    string
+	code    string
+	message string
+	detail  string
+}
+
+func (f *fault) body() map[string]interface{} {
+	return map[string]interface{}{
+		"type": f.kind,
+		"Error": []map[string]string{{
+			"Message": f.message,
+			"Detail":  f.detail,
+			"code":    f.code,
+			"element": "",
+		}},
+	}
+}
+
+func (f *fault) write(w http.ResponseWriter) {
+	writeJSON(w, f.status, map[string]interface{}{"Fault": f.body()})
+}
+
+func writeFault(w http.ResponseWriter, status int, kind, code, message, detail string) {
+	(&fault{status: status, kind: kind, code: code, message: message, detail: detail}).write(w)
+}
+
+func notFound(name, id string) *fault {
+	return &fault{
+		status:  http.StatusBadRequest,
+		kind:    "ValidationFault",
+		code:    "610",
+		message: "Object Not Found",
+		detail:  fmt.Sprintf("Object Not Found : Something you're trying to use has been made inactive. Check the fields with accounts, customers, items, vendors or employees. (%s %s)", name, id),
+	}
+}
+
+func invalid(detail string) *fault {
+	return &fault{status: http.StatusBadRequest, kind: "ValidationFault", code: "2500", message: "Invalid Reference Id", detail: detail}
+}
+
+// tombstone records a deleted entity for change data capture.
+type tombstone struct {
+	name    string
+	id      string
+	deleted time.Time
+}
+
+// realm is the data of one company.
+type realm struct {
+	id       string
+	nextId   int
+	entities map[string]map[string]map[string]interface{}
+	deleted  []tombstone
+	files    map[string][]byte
+}
+
+func newRealm(id string) *realm {
+	return &realm{
+		id:       id,
+		nextId:   1,
+		entities: make(map[string]map[string]map[string]interface{}),
+		files:    make(map[string][]byte),
+	}
+}
+
+func (r *realm) collection(name string) map[string]map[string]interface{} {
+	objects, ok := r.entities[name]
+	if !ok {
+		objects = make(map[string]map[string]interface{})
+		r.entities[name] = objects
+	}
+	return objects
+}
+
+func (r *realm) read(name, id string) (map[string]interface{}, *fault) {
+	if name == "CompanyInfo" {
+		return r.companyInfo(), nil
+	}
+
+	object, ok := r.collection(name)[id]
+	if !ok {
+		return nil, notFound(name, id)
+	}
+	return object, nil
+}
+
+func (r *realm) companyInfo() map[string]interface{} {
+	objects := r.collection("CompanyInfo")
+	if info, ok := objects[r.id]; ok {
+		return info
+	}
+
+	info := map[string]interface{}{
+		"Id":          r.id,
+		"SyncToken":   "0",
+		"CompanyName": "Sandbox Company_US_1",
+		"LegalName":   "Sandbox Company_US_1",
+		"Country":     "US",
+		"domain":      "QBO",
+	}
+	objects[r.id] = info
+	return info
+}
+
+// put stores object without any checks.
+func (r *realm) put(name string, object map[string]interface{}, now time.Time) string {
+	id, _ := object["Id"].(string)
+	if id == "" {
+		id = r.newId()
+		object["Id"] = id
+	}
+	if token, _ := object["SyncToken"].(string); token == "" {
+		object["SyncToken"] = "0"
+	}
+	normalize(object, now)
+	if _, ok := object["MetaData"].(map[string]interface{}); !ok {
+		stamp := now.Format(timeFormat)
+		object["MetaData"] = map[string]interface{}{"CreateTime": stamp, "LastUpdatedTime": stamp}
+	}
+	object["domain"] = "QBO"
+
+	r.collection(name)[id] = object
+	return id
+}
+
+func (r *realm) newId() string {
+	id := strconv.Itoa(r.nextId)
+	r.nextId++
+	return id
+}
+
+// write applies a create, update, delete or void sent to the entity
+// endpoint or in a batch.
+func (r *realm) write(name, operation string, object map[string]interface{}, now time.Time) (map[string]interface{}, *fault) {
+	id, _ := object["Id"].(string)
+
+	if name == "CompanyInfo" {
+		id = r.id
+		r.companyInfo()
+	}
+
+	switch operation {
+	case "delete":
+		existing, f := r.checkSyncToken(name, id, object)
+		if f != nil {
+			return nil, f
+		}
+		delete(r.collection(name), id)
+		r.deleted = append(r.deleted, tombstone{name: name, id: id, deleted: now})
+		return map[string]interface{}{"Id": existing["Id"], "status": "Deleted", "domain": "QBO"}, nil
+
+	case "void":
+		existing, f := r.checkSyncToken(name, id, object)
+		if f != nil {
+			return nil, f
+		}
+		for _, field := range []string{"TotalAmt", "Balance", "Amount"} {
+			if _, ok := existing[field]; ok {
+				existing[field] = json.Number("0")
+			}
+		}
+		existing["PrivateNote"] = "Voided"
+		touch(existing, now)
+		return existing, nil
+
+	case "", "update":
+		if id == "" {
+			if operation == "update" {
+				return nil, invalid("Id is required for an update")
+			}
+			object["SyncToken"] = "0"
+			delete(object, "sparse")
+			normalize(object, now)
+			stamp := now.Format(timeFormat)
+			object["MetaData"] = map[string]interface{}{"CreateTime": stamp, "LastUpdatedTime": stamp}
+			object["Id"] = r.newId()
+			object["domain"] = "QBO"
+			r.collection(name)[object["Id"].(string)] = object
+			return object, nil
+		}
+
+		existing, f := r.checkSyncToken(name, id, object)
+		if f != nil {
+			return nil, f
+		}
+
+		sparse, _ := object["sparse"].(bool)
+		delete(object, "sparse")
+		delete(object, "MetaData")
+		normalize(object, now)
+
+		updated := object
+		if sparse {
+			updated = existing
+			for key, value := range object {
+				updated[key] = value
+			}
+		}
+		updated["Id"] = id
+		updated["domain"] = "QBO"
+		updated["MetaData"] = existing["MetaData"]
+		updated["SyncToken"] = existing["SyncToken"]
+		touch(updated, now)
+
+		r.collection(name)[id] = updated
+		return updated, nil
+	}
+
+	return nil, invalid("Unsupported operation " + operation)
+}
+
+// checkSyncToken returns the stored entity if object carries its current
+// SyncToken, and a stale object fault otherwise.
+func (r *realm) checkSyncToken(name, id string, object map[string]interface{}) (map[string]interface{}, *fault) {
+	if id == "" {
+		return nil, invalid("Id is required")
+	}
+
+	existing, f := r.read(name, id)
+	if f != nil {
+		return nil, f
+	}
+
+	if token, _ := object["SyncToken"].(string); token != existing["SyncToken"] {
+		return nil, &fault{
+			status:  http.StatusBadRequest,
+			kind:    "ValidationFault",
+			code:    "5010",
+			message: "Stale Object Error",
+			detail:  "Stale Object Error : You and qbotest were working on this at the same time. qbotest finished before you did, so your work was not saved.",
+		}
+	}
+
+	return existing, nil
+}
+
+// touch bumps the SyncToken and LastUpdatedTime of an updated object.
+func touch(object map[string]interface{}, now time.Time) {
+	token, _ := strconv.Atoi(fmt.Sprint(object["SyncToken"]))
+	object["SyncToken"] = strconv.Itoa(token + 1)
+
+	metaData, _ := object["MetaData"].(map[string]interface{})
+	if metaData == nil {
+		metaData = map[string]interface{}{"CreateTime": now.Format(timeFormat)}
+	}
+	metaData["LastUpdatedTime"] = now.Format(timeFormat)
+	object["MetaData"] = metaData
+}
+
+// normalize stores date-only fields as YYYY-MM-DD, defaulting a missing
+// TxnDate to today like QuickBooks does.
+func normalize(object map[string]interface{}, now time.Time) {
+	for key, value := range object {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			normalize(v, now)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					normalize(m, now)
+				}
+			}
+		case string:
+			if !dateFields[key] {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				continue
+			}
+			if t.IsZero() {
+				delete(object, key)
+				continue
+			}
+			object[key] = t.Format("2006-01-02")
+		}
+	}
+
+	if _, ok := object["TxnDate"]; !ok {
+		if _, isTxn := object["Line"]; isTxn {
+			object["TxnDate"] = now.Format("2006-01-02")
+		}
+	}
+}
+
+// lastUpdated returns the LastUpdatedTime of a stored object.
+func lastUpdated(object map[string]interface{}) time.Time {
+	metaData, _ := object["MetaData"].(map[string]interface{})
+	stamp, _ := metaData["LastUpdatedTime"].(string)
+	t, _ := time.Parse(time.RFC3339, stamp)
+	return t
+}
+
+// entityName maps the resource of a URL, such as "invoice", to the entity
+// name, such as "Invoice".
+func entityName(resource string) (string, bool) {
+	if strings.EqualFold(resource, "CompanyInfo") {
+		return "CompanyInfo", true
+	}
+	for _, name := range quickbooks.EntityNames() {
+		if strings.EqualFold(name, resource) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// toObject converts a quickbooks entity to its name and JSON object.
+func toObject(entity interface{}) (string, map[string]interface{}, error) {
+	t := reflect.TypeOf(entity)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "", nil, fmt.Errorf("nil entity")
+	}
+
+	name, ok := entityName(t.Name())
+	if !ok || t.PkgPath() != reflect.TypeFor[quickbooks.Invoice]().PkgPath() {
+		return "", nil, fmt.Errorf("%s is not a quickbooks entity", t)
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var object map[string]interface{}
+	if err := decodeJSON(bytes.NewReader(data), &object); err != nil {
+		return "", nil, err
+	}
+	if metaData, ok := object["MetaData"].(map[string]interface{}); ok {
+		if stamp, _ := metaData["CreateTime"].(string); strings.HasPrefix(stamp, "0001-01-01") {
+			delete(object, "MetaData")
+		}
+	}
+
+	return name, object, nil
+}
+
+// decodeJSON decodes keeping numbers as json.Number, so amounts are
+// returned exactly as they were sent.
+func decodeJSON(r io.Reader, v interface{}) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
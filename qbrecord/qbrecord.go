@@ -0,0 +1,427 @@
+// Package qbrecord records HTTP exchanges with QuickBooks to cassette files
+// and replays them, so integration tests run deterministically in CI.
+//
+// Record once against the sandbox, then replay:
+//
+//	rec, err := qbrecord.New(qbrecord.Options{
+//		Mode:         qbrecord.Replay,
+//		Path:         "testdata/invoices.json",
+//		RedactFields: []string{"Employee.SSN"},
+//	})
+//	client, err := quickbooks.NewClient(quickbooks.ClientRequest{
+//		Client: rec.Client(),
+//		...
+//	})
+//	...
+//	if err := rec.Err(); err != nil {
+//		t.Fatal(err)
+//	}
+//
+// Recorded secrets are replaced with Redacted: the Authorization header,
+// OAuth tokens, authorization codes and client secrets, along with any
+// RedactFields.
+package qbrecord
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces secrets in cassettes.
+const Redacted = "REDACTED"
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	// Replay answers requests from the cassette without touching the
+	// network.
+	Replay Mode = iota
+	// Record sends requests through Options.Transport and records them.
+	Record
+)
+
+// secretFields are the JSON fields always redacted.
+var secretFields = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_secret": true,
+}
+
+// secretParams are the form fields and query parameters always redacted.
+// They include the authorization code and the token being revoked.
+var secretParams = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+	"code":          true,
+	"token":         true,
+}
+
+// secretHeaders are the headers always redacted.
+var secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Options configures a Recorder.
+type Options struct {
+	Mode Mode
+	// Path is the cassette file.
+	Path string
+	// Transport sends requests in Record mode. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+	// RedactFields are further JSON fields to redact. "SSN" redacts every
+	// SSN field, "Employee.SSN" only those of an Employee object.
+	RedactFields []string
+	// IgnoreQuery lists query parameters left out when matching requests,
+	// such as "requestid".
+	IgnoreQuery []string
+}
+
+// Cassette is the file format of recorded exchanges.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper recording or replaying a cassette.
+type Recorder struct {
+	opts Options
+
+	mu        sync.Mutex
+	cassette  Cassette
+	used      []bool
+	unmatched []error
+}
+
+// New returns a Recorder. In Replay mode the cassette is loaded from
+// opts.Path and must exist.
+func New(opts Options) (*Recorder, error) {
+	if opts.Path == "" {
+		return nil, errors.New("qbrecord: missing cassette path")
+	}
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+
+	r := &Recorder{opts: opts}
+
+	if opts.Mode == Replay {
+		data, err := os.ReadFile(opts.Path)
+		if err != nil {
+			return nil, fmt.Errorf("qbrecord: failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("qbrecord: failed to decode cassette %s: %v", opts.Path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns an http.Client using the Recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.opts.Mode == Replay {
+		return r.replay(req)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	query := r.normalizeQuery(req.URL.Query())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		recorded := interaction.Request
+		if r.used[i] || recorded.Method != req.Method || recorded.Path != req.URL.Path || r.normalizeQuery(parseQuery(recorded.Query)) != query {
+			continue
+		}
+		r.used[i] = true
+
+		return &http.Response{
+			StatusCode:    interaction.Response.StatusCode,
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	err := fmt.Errorf("qbrecord: no recorded interaction left in %s for %s %s?%s", r.opts.Path, req.Method, req.URL.Path, query)
+	r.unmatched = append(r.unmatched, err)
+	return nil, err
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := resp.Header.Clone()
+	if header.Get("Content-Encoding") == "gzip" {
+		// Cassettes hold plain text so they can be redacted and reviewed.
+		zr, err := gzip.NewReader(bytes.NewReader(respBody))
+		if err != nil {
+			return nil, fmt.Errorf("qbrecord: failed to decompress response: %v", err)
+		}
+		if respBody, err = io.ReadAll(zr); err != nil {
+			return nil, fmt.Errorf("qbrecord: failed to decompress response: %v", err)
+		}
+		header.Del("Content-Encoding")
+		header.Del("Content-Length")
+	}
+
+	query := req.URL.Query()
+	for key := range query {
+		if secretParams[key] {
+			query.Set(key, Redacted)
+		}
+	}
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  query.Encode(),
+			Header: redactHeader(req.Header),
+			Body:   r.redactBody(requestRoot(req.URL.Path), req.Header.Get("Content-Type"), body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(header),
+			Body:       r.redactBody(nil, header.Get("Content-Type"), respBody),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	// The caller gets the response decompressed, as it will be replayed,
+	// but with its secrets intact.
+	resp.Header = header
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	resp.ContentLength = int64(len(respBody))
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette. It does nothing in
+// Replay mode.
+func (r *Recorder) Save() error {
+	if r.opts.Mode == Replay {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("qbrecord: failed to encode cassette: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.opts.Path), 0o755); err != nil {
+		return fmt.Errorf("qbrecord: failed to create cassette directory: %v", err)
+	}
+
+	return os.WriteFile(r.opts.Path, append(data, '\n'), 0o644)
+}
+
+// Err reports the requests that found no interaction to replay, even when
+// the code under test swallowed the error returned by RoundTrip.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return errors.Join(r.unmatched...)
+}
+
+// Unused returns the recorded interactions that were never replayed.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// normalizeQuery encodes the query with its parameters sorted, leaving out
+// the ignored ones.
+func (r *Recorder) normalizeQuery(query url.Values) string {
+	for _, key := range r.opts.IgnoreQuery {
+		query.Del(key)
+	}
+	for key := range query {
+		if secretParams[key] {
+			query.Set(key, Redacted)
+		}
+	}
+	return query.Encode()
+}
+
+func parseQuery(raw string) url.Values {
+	query, _ := url.ParseQuery(raw)
+	return query
+}
+
+// redactHeader redacts the secret headers, dropping Content-Length which no
+// longer holds once the body is redacted.
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	header.Del("Content-Length")
+	for _, key := range secretHeaders {
+		if header.Get(key) != "" {
+			header.Set(key, Redacted)
+		}
+	}
+	return header
+}
+
+// requestRoot returns the entity a request body holds at its root, such as
+// Employee for a POST to /v3/company/{realm}/employee, so RedactFields like
+// "Employee.SSN" match it.
+func requestRoot(path string) []string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) == 4 && parts[0] == "v3" && parts[1] == "company" {
+		return []string{parts[3]}
+	}
+	return nil
+}
+
+// redactBody redacts a body whose root is at path.
+func (r *Recorder) redactBody(path []string, contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return string(body)
+		}
+		for key := range form {
+			if secretParams[key] {
+				form.Set(key, Redacted)
+			}
+		}
+		return form.Encode()
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+
+	r.redactJSON(path, value)
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+// redactJSON walks the value, redacting secret keys and RedactFields. path
+// holds the object keys leading to value.
+func (r *Recorder) redactJSON(path []string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := append(path[:len(path):len(path)], key)
+			if secretFields[key] || r.redactField(childPath) {
+				v[key] = Redacted
+				continue
+			}
+			r.redactJSON(childPath, child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			r.redactJSON(path, child)
+		}
+	}
+}
+
+func (r *Recorder) redactField(path []string) bool {
+	for _, field := range r.opts.RedactFields {
+		parts := strings.Split(field, ".")
+		if len(parts) > len(path) {
+			continue
+		}
+		tail := path[len(path)-len(parts):]
+		match := true
+		for i := range parts {
+			if !strings.EqualFold(parts[i], tail[i]) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package qbrecord_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tommyhedley/quickbooks-go"
+	"github.com/tommyhedley/quickbooks-go/qbotest"
+	"github.com/tommyhedley/quickbooks-go/qbrecord"
+)
+
+const realmId = "4620816365"
+
+// run exercises the client the same way when recording and replaying.
+func run(t *testing.T, client *quickbooks.Client, code string) (*quickbooks.Employee, error) {
+	token, err := client.RetrieveBearerToken(code, "https://example.com/callback")
+	require.NoError(t, err)
+
+	params := quickbooks.RequestParameters{Ctx: context.Background(), WaitOnRateLimit: true, RealmId: realmId, Token: token}
+
+	employee, err := client.CreateEmployee(params, &quickbooks.Employee{GivenName: "Emily", FamilyName: "Platt", SSN: "444-55-6666"})
+	require.NoError(t, err)
+
+	return client.FindEmployeeById(params, employee.Id)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "employees.json")
+	opts := qbrecord.Options{Path: cassette, RedactFields: []string{"Employee.SSN"}}
+
+	server := qbotest.NewServer(qbotest.Options{})
+	req := server.ClientRequest()
+
+	recordOpts := opts
+	recordOpts.Mode = qbrecord.Record
+	recordOpts.Transport = server.Client().Transport
+	recorder, err := qbrecord.New(recordOpts)
+	require.NoError(t, err)
+
+	req.Client = recorder.Client()
+	client, err := quickbooks.NewClient(req)
+	require.NoError(t, err)
+
+	recorded, err := run(t, client, server.AuthorizationCode(realmId))
+	require.NoError(t, err)
+	assert.Equal(t, "444-55-6666", recorded.SSN)
+	require.NoError(t, recorder.Save())
+	server.Close()
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	for _, secret := range []string{"444-55-6666", "access-", "refresh-", "code-", "Bearer ", req.ClientSecret} {
+		assert.NotContains(t, string(data), secret)
+	}
+
+	replayer, err := qbrecord.New(opts)
+	require.NoError(t, err)
+
+	req.Client = replayer.Client()
+	client, err = quickbooks.NewClient(req)
+	require.NoError(t, err)
+
+	replayed, err := run(t, client, "another-code")
+	require.NoError(t, err)
+	assert.Equal(t, recorded.Id, replayed.Id)
+	assert.Equal(t, "Platt", replayed.FamilyName)
+	assert.Equal(t, qbrecord.Redacted, replayed.SSN)
+	assert.Empty(t, replayer.Unused())
+	require.NoError(t, replayer.Err())
+
+	_, err = client.FindEmployeeById(quickbooks.RequestParameters{
+		Ctx:     context.Background(),
+		RealmId: realmId,
+		Token:   &quickbooks.BearerToken{AccessToken: "x"},
+	}, recorded.Id)
+	assert.ErrorContains(t, err, "no recorded interaction")
+	assert.Error(t, replayer.Err())
+}
+
+func TestReplayNormalizesQuery(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "query.json")
+	require.NoError(t, os.WriteFile(cassette, []byte(`{"interactions":[{
+		"request":{"method":"GET","path":"/v3/company/1/query","query":"query=SELECT+%2A+FROM+Term&minorversion=75&requestid=a"},
+		"response":{"status_code":200,"header":{"Content-Type":["application/json"]},"body":"{\"QueryResponse\":{}}"}
+	}]}`), 0o644))
+
+	replayer, err := qbrecord.New(qbrecord.Options{Path: cassette, IgnoreQuery: []string{"requestid"}})
+	require.NoError(t, err)
+
+	resp, err := replayer.Client().Get("https://quickbooks.api.intuit.com/v3/company/1/query?minorversion=75&query=SELECT%20*%20FROM%20Term&requestid=b")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode)
+	require.NoError(t, replayer.Err())
+}
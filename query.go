@@ -0,0 +1,205 @@
+package quickbooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Query lazily runs a query against the entity T, fetching QueryPageSize
+// results at a time with STARTPOSITION and MAXRESULTS as the consumer asks
+// for them. Paging stops as soon as the consumer breaks out of the loop.
+//
+// The query must not contain STARTPOSITION or MAXRESULTS. It is ordered by Id
+// unless it has its own ORDERBY clause, and an empty query selects every
+// entity of type T.
+//
+//	for invoice, err := range quickbooks.Query[quickbooks.Invoice](ctx, client, params, "SELECT * FROM Invoice WHERE Balance > '0'") {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Query[T Entity](ctx context.Context, c *Client, params RequestParameters, query string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		name := EntityName[T]()
+
+		query, err := pagedQuery(name, query)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		params.Ctx = ctx
+
+		for start := 1; ; start += QueryPageSize {
+			var resp struct {
+				QueryResponse map[string]json.RawMessage
+			}
+
+			q := query + " STARTPOSITION " + strconv.Itoa(start) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
+			if err := c.query(params, q, &resp); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var page []T
+			if raw, ok := resp.QueryResponse[name]; ok {
+				if err := json.Unmarshal(raw, &page); err != nil {
+					yield(zero, fmt.Errorf("failed to unmarshal %s page: %v", name, err))
+					return
+				}
+			}
+
+			for _, entity := range page {
+				if !yield(entity, nil) {
+					return
+				}
+			}
+
+			if len(page) < QueryPageSize {
+				return
+			}
+		}
+	}
+}
+
+// pagedQuery checks that the query can be paged and adds the default
+// statement and ordering.
+func pagedQuery(name, query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		query = "SELECT * FROM " + name
+	}
+
+	keywords := queryKeywords(query)
+	if keywords["STARTPOSITION"] || keywords["MAXRESULTS"] {
+		return "", errors.New("query must not set STARTPOSITION or MAXRESULTS")
+	}
+	if !keywords["ORDERBY"] {
+		query += " ORDERBY Id"
+	}
+
+	return query, nil
+}
+
+// queryKeywords returns the upper-cased words of the query, leaving out
+// those inside quoted literals so that a value such as 'Orderby Inc' is not
+// taken for a clause.
+func queryKeywords(query string) map[string]bool {
+	keywords := make(map[string]bool)
+
+	var word strings.Builder
+	inLiteral, escaped := false, false
+	for _, r := range query + " " {
+		switch {
+		case inLiteral:
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '\'':
+				inLiteral = false
+			}
+		case r == '\'':
+			inLiteral = true
+			fallthrough
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_':
+			if word.Len() > 0 {
+				keywords[strings.ToUpper(word.String())] = true
+				word.Reset()
+			}
+		default:
+			word.WriteRune(r)
+		}
+	}
+
+	return keywords
+}
+
+// queryAll collects every entity matched by the query.
+func queryAll[T Entity](c *Client, params RequestParameters, query string) ([]T, error) {
+	var entities []T
+
+	for entity, err := range Query[T](params.Ctx, c, params, query) {
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
+
+// QueryStatement is a statement of the QuickBooks query language, as taken
+// by the QueryXs functions. qb.SelectStatement builds one from typed
+// conditions, and RawQuery passes a written one through as is.
+type QueryStatement interface {
+	Build() (string, error)
+}
+
+// RawQuery is a statement written by hand. Values concatenated into it must
+// be formatted with QueryLiteral.
+type RawQuery string
+
+// Build returns the statement unchanged.
+func (q RawQuery) Build() (string, error) {
+	return string(q), nil
+}
+
+// QueryLiteral formats a value as a literal of the QuickBooks query language.
+// Strings are quoted with their backslashes and apostrophes escaped, Date
+// values are formatted as days and time.Time and DateTime values as full
+// timestamps. Numbers are quoted, as QuickBooks compares them as strings.
+func QueryLiteral(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return quoteQueryString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return quoteQueryString(fmt.Sprint(v)), nil
+	case float32:
+		return quoteQueryString(strconv.FormatFloat(float64(v), 'f', -1, 32)), nil
+	case float64:
+		return quoteQueryString(strconv.FormatFloat(v, 'f', -1, 64)), nil
+	case json.Number:
+		if _, err := v.Float64(); err != nil {
+			return "", fmt.Errorf("invalid number %q", v)
+		}
+		return quoteQueryString(v.String()), nil
+	case time.Time:
+		return quoteQueryString(v.Format(dateFormat)), nil
+	case Date:
+		return quoteQueryString(v.Format(dayFormat)), nil
+	case *Date:
+		if v == nil {
+			return "", errors.New("nil date")
+		}
+		return quoteQueryString(v.Format(dayFormat)), nil
+	case DateTime:
+		return quoteQueryString(v.Format(dateFormat)), nil
+	case *DateTime:
+		if v == nil {
+			return "", errors.New("nil datetime")
+		}
+		return quoteQueryString(v.Format(dateFormat)), nil
+	}
+
+	return "", fmt.Errorf("unsupported query value of type %T", value)
+}
+
+// quoteQueryString quotes s, escaping backslashes and apostrophes.
+func quoteQueryString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "'", `\'`)
+	return "'" + s + "'"
+}
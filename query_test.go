@@ -0,0 +1,100 @@
+package quickbooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pagingPattern = regexp.MustCompile(`STARTPOSITION (\d+) MAXRESULTS (\d+)$`)
+
+// newPagingTestClient serves total customers, recording every query it gets.
+func newPagingTestClient(t *testing.T, total int) (*Client, RequestParameters, func() []string) {
+	var mu sync.Mutex
+	var queries []string
+
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("query")
+		mu.Lock()
+		queries = append(queries, query)
+		mu.Unlock()
+
+		m := pagingPattern.FindStringSubmatch(query)
+		require.NotNil(t, m)
+		start, _ := strconv.Atoi(m[1])
+		max, _ := strconv.Atoi(m[2])
+
+		var customers []string
+		for i := start; i < start+max && i <= total; i++ {
+			customers = append(customers, fmt.Sprintf(`{"Id":"%d"}`, i))
+		}
+		fmt.Fprintf(w, `{"QueryResponse":{"Customer":[%s],"startPosition":%d,"maxResults":%d}}`, strings.Join(customers, ","), start, len(customers))
+	})
+
+	return client, params, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), queries...)
+	}
+}
+
+func TestQueryPagesLazily(t *testing.T) {
+	client, params, queries := newPagingTestClient(t, 2500)
+
+	var ids []string
+	for customer, err := range Query[Customer](context.Background(), client, params, "SELECT * FROM Customer WHERE Active = true") {
+		require.NoError(t, err)
+		ids = append(ids, customer.Id)
+		if len(ids) == 1500 {
+			break
+		}
+	}
+
+	assert.Len(t, ids, 1500)
+	assert.Equal(t, "1500", ids[1499])
+	assert.Equal(t, []string{
+		"SELECT * FROM Customer WHERE Active = true ORDERBY Id STARTPOSITION 1 MAXRESULTS 1000",
+		"SELECT * FROM Customer WHERE Active = true ORDERBY Id STARTPOSITION 1001 MAXRESULTS 1000",
+	}, queries())
+}
+
+func TestFindCustomersReadsEveryPage(t *testing.T) {
+	client, params, queries := newPagingTestClient(t, 2000)
+
+	customers, err := client.FindCustomers(params)
+	require.NoError(t, err)
+	assert.Len(t, customers, 2000)
+
+	// A full last page needs one more, empty, page to know it was the last.
+	assert.Len(t, queries(), 3)
+}
+
+func TestQueryRejectsPaging(t *testing.T) {
+	client, params, queries := newPagingTestClient(t, 10)
+
+	for _, err := range Query[Customer](context.Background(), client, params, "SELECT * FROM Customer MAXRESULTS 10") {
+		require.Error(t, err)
+	}
+	assert.Empty(t, queries())
+}
+
+func TestPagedQueryIgnoresLiterals(t *testing.T) {
+	query, err := pagedQuery("Customer", "SELECT * FROM Customer WHERE DisplayName = 'Orderby Inc'")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM Customer WHERE DisplayName = 'Orderby Inc' ORDERBY Id", query)
+
+	query, err = pagedQuery("Customer", `SELECT * FROM Customer WHERE CompanyName = 'O\'Brien MaxResults' ORDERBY DisplayName`)
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT * FROM Customer WHERE CompanyName = 'O\'Brien MaxResults' ORDERBY DisplayName`, query)
+
+	_, err = pagedQuery("Customer", "SELECT * FROM Customer WHERE DisplayName = 'Ada' MAXRESULTS 5")
+	assert.Error(t, err)
+}
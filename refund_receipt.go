@@ -0,0 +1,446 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// RefundReceipt represents a QuickBooks RefundReceipt object, money paid back
+// to a customer from DepositToAccountRef.
+type RefundReceipt struct {
+	Line                  []Line               `json:",omitempty"`
+	LinkedTxn             []LinkedTxn          `json:",omitempty"`
+	CustomField           []CustomField        `json:",omitempty"`
+	TxnTaxDetail          *TxnTaxDetail        `json:",omitempty"`
+	CustomerRef           *ReferenceType       `json:",omitempty"`
+	ClassRef              *ReferenceType       `json:",omitempty"`
+	DepartmentRef         *ReferenceType       `json:",omitempty"`
+	RecurDataRef          *ReferenceType       `json:",omitempty"`
+	TaxExemptionRef       *ReferenceType       `json:",omitempty"`
+	PaymentMethodRef      *ReferenceType       `json:",omitempty"`
+	DepositToAccountRef   *ReferenceType       `json:",omitempty"`
+	CurrencyRef           ReferenceType        `json:",omitempty"`
+	ProjectRef            ReferenceType        `json:",omitempty"`
+	ShipFromAddr          PhysicalAddress      `json:",omitempty"`
+	ShipAddr              *PhysicalAddress     `json:",omitempty"`
+	BillAddr              *PhysicalAddress     `json:",omitempty"`
+	BillEmail             EmailAddress         `json:",omitempty"`
+	TxnDate               *Date                `json:",omitempty"`
+	CustomerMemo          MemoRef              `json:",omitempty"`
+	MetaData              ModificationMetaData `json:",omitzero"`
+	ExchangeRate          json.Number          `json:",omitempty"`
+	TotalAmt              json.Number          `json:",omitempty"`
+	HomeTotalAmt          json.Number          `json:",omitempty"`
+	Balance               json.Number          `json:",omitempty"`
+	Id                    string               `json:",omitempty"`
+	DocNumber             string               `json:",omitempty"`
+	SyncToken             string               `json:",omitempty"`
+	PrivateNote           string               `json:",omitempty"`
+	PaymentRefNum         string               `json:",omitempty"`
+	PaymentType           string               `json:",omitempty"`
+	PrintStatus           string               `json:",omitempty"`
+	EmailStatus           string               `json:",omitempty"`
+	TxnSource             string               `json:",omitempty"`
+	GlobalTaxCalculation  string               `json:",omitempty"`
+	ApplyTaxAfterDiscount bool                 `json:",omitempty"`
+	FreeFormAddress       bool                 `json:",omitempty"`
+	Domain                string               `json:"domain,omitempty"`
+	Status                string               `json:"status,omitempty"`
+	// CheckPayment
+	// CreditCardPayment
+	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that RefundReceipt does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a RefundReceipt, keeping the fields it does not model in Unknown.
+func (r *RefundReceipt) UnmarshalJSON(data []byte) error {
+	type refundReceipt RefundReceipt
+	return unmarshalWithUnknown(data, (*refundReceipt)(r), &r.Unknown)
+}
+
+// MarshalJSON encodes a RefundReceipt along with its Unknown fields.
+func (r RefundReceipt) MarshalJSON() ([]byte, error) {
+	type refundReceipt RefundReceipt
+	return marshalWithUnknown(refundReceipt(r), r.Unknown)
+}
+
+// CreateRefundReceipt creates the given RefundReceipt on the QuickBooks server,
+// returning the resulting RefundReceipt object.
+func (c *Client) CreateRefundReceipt(params RequestParameters, refundReceipt *RefundReceipt) (*RefundReceipt, error) {
+	var resp struct {
+		RefundReceipt RefundReceipt
+		Time          Date
+	}
+
+	if err := c.post(params, "refundreceipt", refundReceipt, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return &resp.RefundReceipt, nil
+}
+
+// DeleteRefundReceipt deletes the refund receipt.
+func (c *Client) DeleteRefundReceipt(params RequestParameters, refundReceipt *RefundReceipt) error {
+	if refundReceipt.Id == "" || refundReceipt.SyncToken == "" {
+		return errors.New("missing id/sync token")
+	}
+
+	return c.post(params, "refundreceipt", refundReceipt, nil, map[string]string{"operation": "delete"})
+}
+
+// FindRefundReceipts gets the full list of RefundReceipts in the QuickBooks account.
+func (c *Client) FindRefundReceipts(params RequestParameters) ([]RefundReceipt, error) {
+	return queryAll[RefundReceipt](c, params, "")
+}
+
+func (c *Client) FindRefundReceiptsByPage(params RequestParameters, startPosition, pageSize int) ([]RefundReceipt, error) {
+	var resp struct {
+		QueryResponse struct {
+			RefundReceipts []RefundReceipt `json:"RefundReceipt"`
+			MaxResults     int
+			StartPosition  int
+			TotalCount     int
+		}
+	}
+
+	query := "SELECT * FROM RefundReceipt ORDERBY Id STARTPOSITION " + strconv.Itoa(startPosition) + " MAXRESULTS " + strconv.Itoa(pageSize)
+
+	if err := c.query(params, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QueryResponse.RefundReceipts, nil
+}
+
+// FindRefundReceiptById finds the refund receipt by the given id
+func (c *Client) FindRefundReceiptById(params RequestParameters, id string) (*RefundReceipt, error) {
+	var resp struct {
+		RefundReceipt RefundReceipt
+		Time          Date
+	}
+
+	if err := c.get(params, "refundreceipt/"+id, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return &resp.RefundReceipt, nil
+}
+
+// QueryRefundReceipts accepts an SQL query and returns all refund receipts found using it
+func (c *Client) QueryRefundReceipts(params RequestParameters, query QueryStatement) ([]RefundReceipt, error) {
+	var resp struct {
+		QueryResponse struct {
+			RefundReceipts []RefundReceipt `json:"RefundReceipt"`
+			StartPosition  int
+			MaxResults     int
+		}
+	}
+
+	if err := c.queryStatement(params, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QueryResponse.RefundReceipts, nil
+}
+
+// SendRefundReceipt sends the refund receipt to the RefundReceipt.BillEmail if emailAddress is left empty
+func (c *Client) SendRefundReceipt(params RequestParameters, refundReceiptId, emailAddress string) error {
+	queryParameters := make(map[string]string)
+
+	if emailAddress != "" {
+		queryParameters["sendTo"] = emailAddress
+	}
+
+	return c.post(params, "refundreceipt/"+refundReceiptId+"/send", nil, nil, queryParameters)
+}
+
+// UpdateRefundReceipt full updates the refund receipt, meaning that missing writable fields will be set to nil/null
+func (c *Client) UpdateRefundReceipt(params RequestParameters, refundReceipt *RefundReceipt) (*RefundReceipt, error) {
+	if refundReceipt.Id == "" {
+		return nil, errors.New("missing refund receipt id")
+	}
+
+	if !params.StrictConcurrency {
+		existingRefundReceipt, err := c.FindRefundReceiptById(params, refundReceipt.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		refundReceipt.SyncToken = existingRefundReceipt.SyncToken
+	}
+
+	payload := struct {
+		*RefundReceipt
+	}{
+		RefundReceipt: refundReceipt,
+	}
+
+	var refundReceiptData struct {
+		RefundReceipt RefundReceipt
+		Time          Date
+	}
+
+	if err := c.post(params, "refundreceipt", payload, &refundReceiptData, nil); err != nil {
+		return nil, conflictError(params, refundReceipt.Id, refundReceipt, err, c.FindRefundReceiptById)
+	}
+
+	return &refundReceiptData.RefundReceipt, nil
+}
+
+// SparseUpdateRefundReceipt updates only fields included in the refund receipt struct, other fields are left unmodified
+func (c *Client) SparseUpdateRefundReceipt(params RequestParameters, refundReceipt *RefundReceipt) (*RefundReceipt, error) {
+	if refundReceipt.Id == "" {
+		return nil, errors.New("missing refund receipt id")
+	}
+
+	if !params.StrictConcurrency {
+		existingRefundReceipt, err := c.FindRefundReceiptById(params, refundReceipt.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		refundReceipt.SyncToken = existingRefundReceipt.SyncToken
+	}
+
+	payload := sparsePayload{refundReceipt}
+
+	var refundReceiptData struct {
+		RefundReceipt RefundReceipt
+		Time          Date
+	}
+
+	if err := c.post(params, "refundreceipt", payload, &refundReceiptData, nil); err != nil {
+		return nil, conflictError(params, refundReceipt.Id, refundReceipt, err, c.FindRefundReceiptById)
+	}
+
+	return &refundReceiptData.RefundReceipt, nil
+}
+
+// VoidRefundReceipt voids the refund receipt, zeroing its amounts while
+// keeping it on record.
+func (c *Client) VoidRefundReceipt(params RequestParameters, refundReceipt RefundReceipt) error {
+	if refundReceipt.Id == "" {
+		return errors.New("missing refund receipt id")
+	}
+
+	existingRefundReceipt, err := c.FindRefundReceiptById(params, refundReceipt.Id)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"Id":        refundReceipt.Id,
+		"SyncToken": existingRefundReceipt.SyncToken,
+		"sparse":    true,
+	}
+
+	return c.post(params, "refundreceipt", payload, nil, map[string]string{"operation": "update", "include": "void"})
+}
+
+// RefundSalesReceipt refunds lines of the sales receipt with the given id
+// from the account its money was deposited to. The whole sale is refunded
+// when lines is empty.
+func (c *Client) RefundSalesReceipt(params RequestParameters, salesReceiptId string, lines []Line) (*RefundReceipt, error) {
+	salesReceipt, err := c.FindSalesReceiptById(params, salesReceiptId)
+	if err != nil {
+		return nil, err
+	}
+
+	refundReceipt, err := NewRefundFromSalesReceipt(salesReceipt, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.CreateRefundReceipt(params, refundReceipt)
+}
+
+// RefundPayment refunds lines, taxed as given by taxDetail, against the
+// payment with the given id, from the account the payment was deposited to.
+// QuickBooks does not link refunds to the payment they refund, so the ids of
+// the payment's earlier refunds are passed in to be counted against it.
+func (c *Client) RefundPayment(params RequestParameters, paymentId string, lines []Line, taxDetail *TxnTaxDetail, earlierRefundIds ...string) (*RefundReceipt, error) {
+	payment, err := c.FindPaymentById(params, paymentId)
+	if err != nil {
+		return nil, err
+	}
+
+	earlier := make([]RefundReceipt, 0, len(earlierRefundIds))
+	for _, id := range earlierRefundIds {
+		refundReceipt, err := c.FindRefundReceiptById(params, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up refund receipt %s: %w", id, err)
+		}
+		earlier = append(earlier, *refundReceipt)
+	}
+
+	refundReceipt, err := NewRefundFromPayment(payment, lines, taxDetail, earlier)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.CreateRefundReceipt(params, refundReceipt)
+}
+
+// NewRefundFromSalesReceipt returns a RefundReceipt for the customer of the
+// sales receipt, paid from its DepositToAccountRef with its payment method.
+// The receipt's lines and tax code are copied when lines is empty.
+//
+// It fails when the lines, before tax, refund more than the receipt's lines
+// charged before tax. Earlier refunds of the same receipt are not counted.
+func NewRefundFromSalesReceipt(salesReceipt *SalesReceipt, lines []Line) (*RefundReceipt, error) {
+	if salesReceipt.DepositToAccountRef == nil {
+		return nil, fmt.Errorf("sales receipt %s has no DepositToAccountRef to refund from", salesReceipt.Id)
+	}
+
+	refundReceipt := &RefundReceipt{
+		CustomerRef:         salesReceipt.CustomerRef,
+		DepositToAccountRef: salesReceipt.DepositToAccountRef,
+		PaymentMethodRef:    salesReceipt.PaymentMethodRef,
+		CurrencyRef:         salesReceipt.CurrencyRef,
+		ExchangeRate:        salesReceipt.ExchangeRate,
+		ClassRef:            salesReceipt.ClassRef,
+		DepartmentRef:       salesReceipt.DepartmentRef,
+		BillAddr:            salesReceipt.BillAddr,
+		BillEmail:           salesReceipt.BillEmail,
+		Line:                lines,
+	}
+
+	if len(lines) == 0 {
+		refundReceipt.Line = copyLines(salesReceipt.Line)
+		if salesReceipt.TxnTaxDetail != nil {
+			refundReceipt.TxnTaxDetail = &TxnTaxDetail{TxnTaxCodeRef: salesReceipt.TxnTaxDetail.TxnTaxCodeRef}
+		}
+	}
+
+	subtotal, err := netAmount(salesReceipt.Line, salesReceipt.CurrencyRef)
+	if err != nil {
+		return nil, fmt.Errorf("sales receipt %s: %v", salesReceipt.Id, err)
+	}
+	if err := checkRefundAmount(refundReceipt.Line, Decimal{}, subtotal, refundReceipt.CurrencyRef, "sales receipt "+salesReceipt.Id+" subtotal"); err != nil {
+		return nil, err
+	}
+
+	return refundReceipt, nil
+}
+
+// NewRefundFromPayment returns a RefundReceipt of lines, taxed as given by
+// taxDetail, for the customer of the payment, paid from its
+// DepositToAccountRef with its payment method.
+//
+// It fails when the lines plus taxDetail.TotalTax refund more than what is
+// left of the payment's TotalAmt after the earlier refunds. Tax left for
+// QuickBooks to work out from a tax code is not known here, so TotalTax must
+// be set for the check to count it.
+func NewRefundFromPayment(payment *Payment, lines []Line, taxDetail *TxnTaxDetail, earlier []RefundReceipt) (*RefundReceipt, error) {
+	if payment.DepositToAccountRef == nil {
+		return nil, fmt.Errorf("payment %s has no DepositToAccountRef to refund from", payment.Id)
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("missing refund lines")
+	}
+
+	customerRef := payment.CustomerRef
+
+	refundReceipt := &RefundReceipt{
+		CustomerRef:         &customerRef,
+		DepositToAccountRef: payment.DepositToAccountRef,
+		PaymentMethodRef:    payment.PaymentMethodRef,
+		CurrencyRef:         payment.CurrencyRef,
+		ExchangeRate:        payment.ExchangeRate,
+		TxnTaxDetail:        taxDetail,
+		Line:                lines,
+	}
+
+	var tax Decimal
+	if taxDetail != nil {
+		var err error
+		if tax, err = DecimalFromNumber(taxDetail.TotalTax); err != nil {
+			return nil, fmt.Errorf("invalid refund TotalTax: %v", err)
+		}
+	}
+
+	limit, err := payment.TotalAmtDecimal()
+	if err != nil {
+		return nil, fmt.Errorf("payment %s: %v", payment.Id, err)
+	}
+	original := "payment " + payment.Id + " total"
+	for _, refund := range earlier {
+		refunded, err := refund.TotalAmtDecimal()
+		if err != nil {
+			return nil, fmt.Errorf("refund receipt %s: %v", refund.Id, err)
+		}
+		limit = limit.Sub(refunded)
+		original = "payment " + payment.Id + " total left after earlier refunds"
+	}
+
+	if err := checkRefundAmount(refundReceipt.Line, tax, limit, refundReceipt.CurrencyRef, original); err != nil {
+		return nil, err
+	}
+
+	return refundReceipt, nil
+}
+
+// copyLines copies the lines of a transaction for use in a new one, without
+// their ids and links.
+func copyLines(lines []Line) []Line {
+	copied := make([]Line, len(lines))
+	for i, line := range lines {
+		line.Id = ""
+		line.LineNum = 0
+		line.LinkedTxn = nil
+		copied[i] = line
+	}
+	return copied
+}
+
+// checkRefundAmount checks that the lines plus tax refund no more than limit.
+func checkRefundAmount(lines []Line, tax, limit Decimal, currency ReferenceType, original string) error {
+	amount, err := netAmount(lines, currency)
+	if err != nil {
+		return err
+	}
+	amount = amount.Add(tax)
+
+	if amount.Cmp(limit) > 0 {
+		return fmt.Errorf("refund amount %s exceeds the %s of %s", amount, original, limit)
+	}
+
+	return nil
+}
+
+// netAmount returns what the lines charge before tax: their sales item and
+// group lines less their discounts. A percent discount is taken on the lines
+// above it.
+func netAmount(lines []Line, currency ReferenceType) (Decimal, error) {
+	var amount Decimal
+	for i, line := range lines {
+		switch line.DetailType {
+		case SalesItemLine, GroupLine:
+			lineAmount, err := line.AmountDecimal()
+			if err != nil {
+				return Decimal{}, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			amount = amount.Add(lineAmount)
+		case DiscountLine:
+			var discount Decimal
+			var err error
+			if line.DiscountLineDetail.PercentBased {
+				var percent Decimal
+				percent, err = DecimalFromNumber(line.DiscountLineDetail.DiscountPercent)
+				discount = amount.Mul(percent).Div(NewDecimal(100, 0), CurrencyDecimals(currency.Value))
+			} else {
+				discount, err = line.AmountDecimal()
+			}
+			if err != nil {
+				return Decimal{}, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			amount = amount.Sub(discount)
+		}
+	}
+
+	return amount, nil
+}
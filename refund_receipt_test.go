@@ -0,0 +1,114 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRefundFromSalesReceipt(t *testing.T) {
+	salesReceipt := &SalesReceipt{
+		Id:                  "11",
+		CustomerRef:         &ReferenceType{Value: "6"},
+		DepositToAccountRef: &ReferenceType{Value: "35"},
+		PaymentMethodRef:    &ReferenceType{Value: "2"},
+		TxnTaxDetail:        &TxnTaxDetail{TxnTaxCodeRef: ReferenceType{Value: "3"}, TotalTax: "8.00"},
+		TotalAmt:            "108.00",
+		Line: []Line{
+			{Id: "1", LineNum: 1, Amount: "100.00", DetailType: SalesItemLine, SalesItemLineDetail: SalesItemLineDetail{ItemRef: ReferenceType{Value: "4"}}},
+			NewSubTotalLine(),
+		},
+	}
+	salesReceipt.Line[1].Amount = "100.00"
+
+	refund, err := NewRefundFromSalesReceipt(salesReceipt, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "6", refund.CustomerRef.Value)
+	assert.Equal(t, "35", refund.DepositToAccountRef.Value)
+	assert.Equal(t, "2", refund.PaymentMethodRef.Value)
+	assert.Equal(t, "3", refund.TxnTaxDetail.TxnTaxCodeRef.Value)
+	require.Len(t, refund.Line, 2)
+	assert.Empty(t, refund.Line[0].Id)
+	assert.Zero(t, refund.Line[0].LineNum)
+	assert.Equal(t, "1", salesReceipt.Line[0].Id)
+
+	_, err = NewRefundFromSalesReceipt(salesReceipt, []Line{
+		mustLine(NewSalesItemLine(ReferenceType{Value: "4"}, "2", "60", "")),
+	})
+	assert.EqualError(t, err, "refund amount 120.00 exceeds the sales receipt 11 subtotal of 100.00")
+
+	partial, err := NewRefundFromSalesReceipt(salesReceipt, []Line{
+		mustLine(NewSalesItemLine(ReferenceType{Value: "4"}, "2", "60", "")),
+		NewDiscountLine("20"),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, partial.TxnTaxDetail)
+
+	// The cap is the receipt's amount before tax and after its discounts.
+	salesReceipt.Line = []Line{
+		{Amount: "200.00", DetailType: SalesItemLine, SalesItemLineDetail: SalesItemLineDetail{ItemRef: ReferenceType{Value: "4"}}},
+		NewPercentDiscountLine("10"),
+	}
+	salesReceipt.TxnTaxDetail.TotalTax = "14.40"
+	salesReceipt.TotalAmt = "194.40"
+
+	_, err = NewRefundFromSalesReceipt(salesReceipt, []Line{mustLine(NewSalesItemLine(ReferenceType{Value: "4"}, "1", "190", ""))})
+	assert.EqualError(t, err, "refund amount 190.00 exceeds the sales receipt 11 subtotal of 180.00")
+
+	_, err = NewRefundFromSalesReceipt(salesReceipt, []Line{
+		mustLine(NewSalesItemLine(ReferenceType{Value: "4"}, "1", "200", "")),
+		NewPercentDiscountLine("10"),
+	})
+	require.NoError(t, err)
+
+	salesReceipt.DepositToAccountRef = nil
+	_, err = NewRefundFromSalesReceipt(salesReceipt, nil)
+	assert.EqualError(t, err, "sales receipt 11 has no DepositToAccountRef to refund from")
+}
+
+func TestRefundPayment(t *testing.T) {
+	var created RefundReceipt
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/company/1234/payment/7":
+			w.Write([]byte(`{"Payment":{"Id":"7","CustomerRef":{"value":"6"},"DepositToAccountRef":{"value":"35"},"TotalAmt":50}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/company/1234/refundreceipt/9":
+			w.Write([]byte(`{"RefundReceipt":{"Id":"9","CustomerRef":{"value":"6"},"TotalAmt":32.40}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/company/1234/refundreceipt":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			created.Id = "12"
+			json.NewEncoder(w).Encode(map[string]interface{}{"RefundReceipt": created})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	refund, err := client.RefundPayment(params, "7", []Line{mustLine(NewSalesItemLine(ReferenceType{Value: "4"}, "1", "50", ""))}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "12", refund.Id)
+	assert.Equal(t, "6", created.CustomerRef.Value)
+	assert.Equal(t, "35", created.DepositToAccountRef.Value)
+
+	_, err = client.RefundPayment(params, "7", []Line{mustLine(NewSalesItemLine(ReferenceType{Value: "4"}, "1", "50.01", ""))}, nil)
+	assert.EqualError(t, err, "refund amount 50.01 exceeds the payment 7 total of 50")
+
+	// Tax is refunded on top of the lines, which the payment's total includes.
+	taxDetail := &TxnTaxDetail{TxnTaxCodeRef: ReferenceType{Value: "3"}, TotalTax: "3.60"}
+	_, err = client.RefundPayment(params, "7", []Line{mustLine(NewSalesItemLine(ReferenceType{Value: "4"}, "1", "48", ""))}, taxDetail)
+	assert.EqualError(t, err, "refund amount 51.60 exceeds the payment 7 total of 50")
+
+	// The earlier refund of 32.40 leaves 17.60 to refund.
+	taxDetail.TotalTax = "1.40"
+	_, err = client.RefundPayment(params, "7", []Line{mustLine(NewSalesItemLine(ReferenceType{Value: "4"}, "1", "16.21", ""))}, taxDetail, "9")
+	assert.EqualError(t, err, "refund amount 17.61 exceeds the payment 7 total left after earlier refunds of 17.60")
+
+	_, err = client.RefundPayment(params, "7", []Line{mustLine(NewSalesItemLine(ReferenceType{Value: "4"}, "1", "16.20", ""))}, taxDetail, "9")
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("1.40"), created.TxnTaxDetail.TotalTax)
+
+	_, err = client.RefundPayment(params, "7", nil, nil)
+	assert.EqualError(t, err, "missing refund lines")
+}
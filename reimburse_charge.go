@@ -11,7 +11,7 @@ type ReimburseCharge struct {
 	CustomerRef     ReferenceType        `json:",omitempty"`
 	CurrencyRef     ReferenceType        `json:",omitempty"`
 	TxnDate         *Date                `json:",omitempty"`
-	MetaData        ModificationMetaData `json:",omitempty"`
+	MetaData        ModificationMetaData `json:",omitzero"`
 	Amount          json.Number          `json:",omitempty"`
 	ExchangeRate    json.Number          `json:",omitempty"`
 	HomeTotalAmt    json.Number          `json:",omitempty"`
@@ -21,40 +21,26 @@ type ReimburseCharge struct {
 	HasBeenInvoiced bool                 `json:",omitempty"`
 	Domain          string               `json:"domain,omitempty"`
 	Status          string               `json:"status,omitempty"`
-}
-
-// FindReimburseCharges gets the full list of ReimburseCharges in the QuickBooks account.
-func (c *Client) FindReimburseCharges(params RequestParameters) ([]ReimburseCharge, error) {
-	var resp struct {
-		QueryResponse struct {
-			ReimburseCharges []ReimburseCharge `json:"ReimburseCharge"`
-			MaxResults       int
-			StartPosition    int
-			TotalCount       int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM ReimburseCharge", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
 
-	reimburseCharges := make([]ReimburseCharge, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM ReimburseCharge ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
+	// Unknown holds the fields QuickBooks sent that ReimburseCharge does not model.
+	Unknown UnknownFields `json:"-"`
+}
 
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
+// UnmarshalJSON decodes a ReimburseCharge, keeping the fields it does not model in Unknown.
+func (r *ReimburseCharge) UnmarshalJSON(data []byte) error {
+	type reimburseCharge ReimburseCharge
+	return unmarshalWithUnknown(data, (*reimburseCharge)(r), &r.Unknown)
+}
 
-		reimburseCharges = append(reimburseCharges, resp.QueryResponse.ReimburseCharges...)
-	}
+// MarshalJSON encodes a ReimburseCharge along with its Unknown fields.
+func (r ReimburseCharge) MarshalJSON() ([]byte, error) {
+	type reimburseCharge ReimburseCharge
+	return marshalWithUnknown(reimburseCharge(r), r.Unknown)
+}
 
-	return reimburseCharges, nil
+// FindReimburseCharges gets the full list of ReimburseCharges in the QuickBooks account.
+func (c *Client) FindReimburseCharges(params RequestParameters) ([]ReimburseCharge, error) {
+	return queryAll[ReimburseCharge](c, params, "")
 }
 
 func (c *Client) FindReimburseChargesByPage(params RequestParameters, startPosition, pageSize int) ([]ReimburseCharge, error) {
@@ -91,7 +77,7 @@ func (c *Client) FindReimburseChargeById(params RequestParameters, id string) (*
 }
 
 // QueryReimburseCharges accepts an SQL query and returns all reimburseCharges found using it
-func (c *Client) QueryReimburseCharges(params RequestParameters, query string) ([]ReimburseCharge, error) {
+func (c *Client) QueryReimburseCharges(params RequestParameters, query QueryStatement) ([]ReimburseCharge, error) {
 	var resp struct {
 		QueryResponse struct {
 			ReimburseCharges []ReimburseCharge `json:"ReimburseCharge"`
@@ -100,7 +86,7 @@ func (c *Client) QueryReimburseCharges(params RequestParameters, query string) (
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,132 @@
+package quickbooks
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how requests that fail with a 429 or 5xx response, or
+// that never reach QuickBooks at all, are retried.
+//
+// Only idempotent requests are retried: reads, and writes that carry an
+// idempotency key in RequestParameters.RequestId.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 1 are treated as 1.
+	MaxAttempts int
+	// InitialBackoff is the base wait before the first retry. It doubles on
+	// every following retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the computed wait between two attempts. A Retry-After
+	// header sent by QuickBooks is honored even when it is longer.
+	MaxBackoff time.Duration
+	// MaxElapsedTime caps the total time spent on a request, waits included.
+	// Zero means no cap.
+	MaxElapsedTime time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable starting point for background workers.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	MaxElapsedTime: 2 * time.Minute,
+}
+
+// next returns how long to wait before the attempt following the given one,
+// or false when the policy does not allow another attempt.
+func (p RetryPolicy) next(attempt int, elapsed time.Duration, retryAfter time.Duration) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	// Equal jitter: wait somewhere between half and all of the backoff.
+	wait := backoff
+	if half := int64(backoff / 2); half > 0 {
+		wait = time.Duration(half + rand.Int64N(half+1))
+	}
+
+	if retryAfter > wait {
+		wait = retryAfter
+	}
+
+	if p.MaxElapsedTime > 0 && elapsed+wait > p.MaxElapsedTime {
+		return 0, false
+	}
+
+	return wait, true
+}
+
+// isRetryableStatus reports whether a response status is worth retrying.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releasingBody releases the rate limiter slots held by a request once its
+// response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,145 @@
+package quickbooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+}
+
+func newRetryTestClient(t *testing.T, policy *RetryPolicy, handler http.HandlerFunc) (*Client, RequestParameters) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientRequest{
+		Client:      server.Client(),
+		Endpoint:    server.URL,
+		RetryPolicy: policy,
+	})
+	require.NoError(t, err)
+
+	params := RequestParameters{
+		Ctx:             context.Background(),
+		WaitOnRateLimit: true,
+		RealmId:         "1234",
+		Token:           &BearerToken{AccessToken: "token"},
+	}
+
+	return client, params
+}
+
+func TestRetryOnServerError(t *testing.T) {
+	var calls atomic.Int32
+	client, params := newRetryTestClient(t, &testRetryPolicy, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"Item":{"Id":"7","Name":"Hours"}}`))
+	})
+
+	item, err := client.FindItemById(params, "7")
+	require.NoError(t, err)
+	assert.Equal(t, "Hours", item.Name)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	policy := testRetryPolicy
+	policy.MaxElapsedTime = 500 * time.Millisecond
+	client, params := newRetryTestClient(t, &policy, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"Item":{"Id":"7"}}`))
+	})
+
+	// The one second Retry-After does not fit in the elapsed time budget.
+	_, err := client.FindItemById(params, "7")
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, apiRl, rateLimitErr.LimitType)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryStopsAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	client, params := newRetryTestClient(t, &testRetryPolicy, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := client.QueryItems(params, RawQuery("SELECT * FROM Item"))
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, int32(testRetryPolicy.MaxAttempts), calls.Load())
+}
+
+func TestRetryWritesNeedRequestId(t *testing.T) {
+	var calls atomic.Int32
+	var requestId atomic.Value
+	client, params := newRetryTestClient(t, &testRetryPolicy, func(w http.ResponseWriter, r *http.Request) {
+		requestId.Store(r.URL.Query().Get("requestid"))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"Item":{"Id":"8","Name":"Nails"}}`))
+	})
+
+	_, err := client.CreateItem(params, &Item{Name: "Nails"})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	calls.Store(0)
+	params.RequestId = "create-nails-1"
+	item, err := client.CreateItem(params, &Item{Name: "Nails"})
+	require.NoError(t, err)
+	assert.Equal(t, "8", item.Id)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, "create-nails-1", requestId.Load())
+}
+
+func TestNoRetryWithoutPolicy(t *testing.T) {
+	var calls atomic.Int32
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := client.GetAttachableDownloadURL(params, "100")
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt := 1; attempt < 10; attempt++ {
+		wait, ok := policy.next(attempt, 0, 0)
+		require.True(t, ok)
+		assert.LessOrEqual(t, wait, policy.MaxBackoff)
+		assert.GreaterOrEqual(t, wait, policy.InitialBackoff/2)
+	}
+
+	_, ok := policy.next(10, 0, 0)
+	assert.False(t, ok)
+
+	wait, ok := policy.next(1, 0, 5*time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, wait)
+}
@@ -0,0 +1,248 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// SalesReceipt represents a QuickBooks SalesReceipt object, a sale paid for
+// in full at the time of the sale.
+type SalesReceipt struct {
+	Line                  []Line               `json:",omitempty"`
+	LinkedTxn             []LinkedTxn          `json:",omitempty"`
+	CustomField           []CustomField        `json:",omitempty"`
+	TxnTaxDetail          *TxnTaxDetail        `json:",omitempty"`
+	CustomerRef           *ReferenceType       `json:",omitempty"`
+	ClassRef              *ReferenceType       `json:",omitempty"`
+	DepartmentRef         *ReferenceType       `json:",omitempty"`
+	ShipMethodRef         *ReferenceType       `json:",omitempty"`
+	RecurDataRef          *ReferenceType       `json:",omitempty"`
+	TaxExemptionRef       *ReferenceType       `json:",omitempty"`
+	PaymentMethodRef      *ReferenceType       `json:",omitempty"`
+	DepositToAccountRef   *ReferenceType       `json:",omitempty"`
+	CurrencyRef           ReferenceType        `json:",omitempty"`
+	ProjectRef            ReferenceType        `json:",omitempty"`
+	ShipFromAddr          PhysicalAddress      `json:",omitempty"`
+	ShipAddr              *PhysicalAddress     `json:",omitempty"`
+	BillAddr              *PhysicalAddress     `json:",omitempty"`
+	BillEmail             EmailAddress         `json:",omitempty"`
+	DeliveryInfo          *DeliveryInfo        `json:",omitempty"`
+	TxnDate               *Date                `json:",omitempty"`
+	ShipDate              *Date                `json:",omitempty"`
+	CustomerMemo          MemoRef              `json:",omitempty"`
+	MetaData              ModificationMetaData `json:",omitzero"`
+	ExchangeRate          json.Number          `json:",omitempty"`
+	TotalAmt              json.Number          `json:",omitempty"`
+	HomeTotalAmt          json.Number          `json:",omitempty"`
+	Balance               json.Number          `json:",omitempty"`
+	Id                    string               `json:",omitempty"`
+	DocNumber             string               `json:",omitempty"`
+	SyncToken             string               `json:",omitempty"`
+	PrivateNote           string               `json:",omitempty"`
+	PaymentRefNum         string               `json:",omitempty"`
+	TrackingNum           string               `json:",omitempty"`
+	PrintStatus           string               `json:",omitempty"`
+	EmailStatus           string               `json:",omitempty"`
+	TxnSource             string               `json:",omitempty"`
+	GlobalTaxCalculation  string               `json:",omitempty"`
+	ApplyTaxAfterDiscount bool                 `json:",omitempty"`
+	FreeFormAddress       bool                 `json:",omitempty"`
+	Domain                string               `json:"domain,omitempty"`
+	Status                string               `json:"status,omitempty"`
+	// CreditCardPayment
+	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that SalesReceipt does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a SalesReceipt, keeping the fields it does not model in Unknown.
+func (s *SalesReceipt) UnmarshalJSON(data []byte) error {
+	type salesReceipt SalesReceipt
+	return unmarshalWithUnknown(data, (*salesReceipt)(s), &s.Unknown)
+}
+
+// MarshalJSON encodes a SalesReceipt along with its Unknown fields.
+func (s SalesReceipt) MarshalJSON() ([]byte, error) {
+	type salesReceipt SalesReceipt
+	return marshalWithUnknown(salesReceipt(s), s.Unknown)
+}
+
+// CreateSalesReceipt creates the given SalesReceipt on the QuickBooks server,
+// returning the resulting SalesReceipt object.
+func (c *Client) CreateSalesReceipt(params RequestParameters, salesReceipt *SalesReceipt) (*SalesReceipt, error) {
+	var resp struct {
+		SalesReceipt SalesReceipt
+		Time         Date
+	}
+
+	if err := c.post(params, "salesreceipt", salesReceipt, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return &resp.SalesReceipt, nil
+}
+
+// DeleteSalesReceipt deletes the sales receipt.
+func (c *Client) DeleteSalesReceipt(params RequestParameters, salesReceipt *SalesReceipt) error {
+	if salesReceipt.Id == "" || salesReceipt.SyncToken == "" {
+		return errors.New("missing id/sync token")
+	}
+
+	return c.post(params, "salesreceipt", salesReceipt, nil, map[string]string{"operation": "delete"})
+}
+
+// FindSalesReceipts gets the full list of SalesReceipts in the QuickBooks account.
+func (c *Client) FindSalesReceipts(params RequestParameters) ([]SalesReceipt, error) {
+	return queryAll[SalesReceipt](c, params, "")
+}
+
+func (c *Client) FindSalesReceiptsByPage(params RequestParameters, startPosition, pageSize int) ([]SalesReceipt, error) {
+	var resp struct {
+		QueryResponse struct {
+			SalesReceipts []SalesReceipt `json:"SalesReceipt"`
+			MaxResults    int
+			StartPosition int
+			TotalCount    int
+		}
+	}
+
+	query := "SELECT * FROM SalesReceipt ORDERBY Id STARTPOSITION " + strconv.Itoa(startPosition) + " MAXRESULTS " + strconv.Itoa(pageSize)
+
+	if err := c.query(params, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QueryResponse.SalesReceipts, nil
+}
+
+// FindSalesReceiptById finds the sales receipt by the given id
+func (c *Client) FindSalesReceiptById(params RequestParameters, id string) (*SalesReceipt, error) {
+	var resp struct {
+		SalesReceipt SalesReceipt
+		Time         Date
+	}
+
+	if err := c.get(params, "salesreceipt/"+id, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return &resp.SalesReceipt, nil
+}
+
+// QuerySalesReceipts accepts an SQL query and returns all sales receipts found using it
+func (c *Client) QuerySalesReceipts(params RequestParameters, query QueryStatement) ([]SalesReceipt, error) {
+	var resp struct {
+		QueryResponse struct {
+			SalesReceipts []SalesReceipt `json:"SalesReceipt"`
+			StartPosition int
+			MaxResults    int
+		}
+	}
+
+	if err := c.queryStatement(params, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QueryResponse.SalesReceipts, nil
+}
+
+// SendSalesReceipt sends the sales receipt to the SalesReceipt.BillEmail if emailAddress is left empty
+func (c *Client) SendSalesReceipt(params RequestParameters, salesReceiptId, emailAddress string) error {
+	queryParameters := make(map[string]string)
+
+	if emailAddress != "" {
+		queryParameters["sendTo"] = emailAddress
+	}
+
+	return c.post(params, "salesreceipt/"+salesReceiptId+"/send", nil, nil, queryParameters)
+}
+
+// GetSalesReceiptPDF returns the sales receipt rendered as a PDF document.
+func (c *Client) GetSalesReceiptPDF(params RequestParameters, salesReceiptId string) ([]byte, error) {
+	return c.pdf(params, "salesreceipt/"+salesReceiptId+"/pdf")
+}
+
+// UpdateSalesReceipt full updates the sales receipt, meaning that missing writable fields will be set to nil/null
+func (c *Client) UpdateSalesReceipt(params RequestParameters, salesReceipt *SalesReceipt) (*SalesReceipt, error) {
+	if salesReceipt.Id == "" {
+		return nil, errors.New("missing sales receipt id")
+	}
+
+	if !params.StrictConcurrency {
+		existingSalesReceipt, err := c.FindSalesReceiptById(params, salesReceipt.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		salesReceipt.SyncToken = existingSalesReceipt.SyncToken
+	}
+
+	payload := struct {
+		*SalesReceipt
+	}{
+		SalesReceipt: salesReceipt,
+	}
+
+	var salesReceiptData struct {
+		SalesReceipt SalesReceipt
+		Time         Date
+	}
+
+	if err := c.post(params, "salesreceipt", payload, &salesReceiptData, nil); err != nil {
+		return nil, conflictError(params, salesReceipt.Id, salesReceipt, err, c.FindSalesReceiptById)
+	}
+
+	return &salesReceiptData.SalesReceipt, nil
+}
+
+// SparseUpdateSalesReceipt updates only fields included in the sales receipt struct, other fields are left unmodified
+func (c *Client) SparseUpdateSalesReceipt(params RequestParameters, salesReceipt *SalesReceipt) (*SalesReceipt, error) {
+	if salesReceipt.Id == "" {
+		return nil, errors.New("missing sales receipt id")
+	}
+
+	if !params.StrictConcurrency {
+		existingSalesReceipt, err := c.FindSalesReceiptById(params, salesReceipt.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		salesReceipt.SyncToken = existingSalesReceipt.SyncToken
+	}
+
+	payload := sparsePayload{salesReceipt}
+
+	var salesReceiptData struct {
+		SalesReceipt SalesReceipt
+		Time         Date
+	}
+
+	if err := c.post(params, "salesreceipt", payload, &salesReceiptData, nil); err != nil {
+		return nil, conflictError(params, salesReceipt.Id, salesReceipt, err, c.FindSalesReceiptById)
+	}
+
+	return &salesReceiptData.SalesReceipt, nil
+}
+
+// VoidSalesReceipt voids the sales receipt, zeroing its amounts while
+// keeping it on record.
+func (c *Client) VoidSalesReceipt(params RequestParameters, salesReceipt SalesReceipt) error {
+	if salesReceipt.Id == "" {
+		return errors.New("missing sales receipt id")
+	}
+
+	existingSalesReceipt, err := c.FindSalesReceiptById(params, salesReceipt.Id)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"Id":        salesReceipt.Id,
+		"SyncToken": existingSalesReceipt.SyncToken,
+		"sparse":    true,
+	}
+
+	return c.post(params, "salesreceipt", payload, nil, map[string]string{"operation": "update", "include": "void"})
+}
@@ -0,0 +1,69 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSalesReceipt(t *testing.T) {
+	jsonFile, err := os.Open("data/testing/sales_receipt.json")
+	require.NoError(t, err)
+	defer jsonFile.Close()
+
+	byteValue, err := io.ReadAll(jsonFile)
+	require.NoError(t, err)
+
+	var resp struct {
+		SalesReceipt SalesReceipt
+		Time         DateTime
+	}
+
+	require.NoError(t, json.Unmarshal(byteValue, &resp))
+	assert.Equal(t, "11", resp.SalesReceipt.Id)
+	assert.Equal(t, "1003", resp.SalesReceipt.DocNumber)
+	assert.Equal(t, "6", resp.SalesReceipt.CustomerRef.Value)
+	assert.Equal(t, "Check", resp.SalesReceipt.PaymentMethodRef.Name)
+	assert.Equal(t, "35", resp.SalesReceipt.DepositToAccountRef.Value)
+	assert.Equal(t, "10264", resp.SalesReceipt.PaymentRefNum)
+	assert.Equal(t, "2014-09-14", resp.SalesReceipt.TxnDate.Format("2006-01-02"))
+	assert.Equal(t, json.Number("337.5"), resp.SalesReceipt.TotalAmt)
+	require.Len(t, resp.SalesReceipt.Line, 2)
+	assert.Equal(t, SalesItemLine, resp.SalesReceipt.Line[0].DetailType)
+	assert.Equal(t, json.Number("4.5"), resp.SalesReceipt.Line[0].SalesItemLineDetail.Qty)
+	assert.Equal(t, "2014-09-16T14:59:48-07:00", resp.SalesReceipt.MetaData.CreateTime.String())
+	assert.Equal(t, "SalesReceipt", EntityName[SalesReceipt]())
+}
+
+func TestVoidSalesReceiptAndPDF(t *testing.T) {
+	var voided map[string]interface{}
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/company/1234/salesreceipt/11":
+			w.Write([]byte(`{"SalesReceipt":{"Id":"11","SyncToken":"3"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/company/1234/salesreceipt":
+			assert.Equal(t, "update", r.URL.Query().Get("operation"))
+			assert.Equal(t, "void", r.URL.Query().Get("include"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&voided))
+			w.Write([]byte(`{"SalesReceipt":{"Id":"11","SyncToken":"4"}}`))
+		case r.URL.Path == "/v3/company/1234/salesreceipt/11/pdf":
+			assert.Equal(t, "application/pdf", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	require.NoError(t, client.VoidSalesReceipt(params, SalesReceipt{Id: "11", SyncToken: "1"}))
+	assert.Equal(t, map[string]interface{}{"Id": "11", "SyncToken": "3", "sparse": true}, voided)
+
+	pdf, err := client.GetSalesReceiptPDF(params, "11")
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4", string(pdf))
+}
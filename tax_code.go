@@ -7,7 +7,7 @@ import (
 type TaxCode struct {
 	PurchaseTaxRateList TaxRateList          `json:",omitempty"`
 	SalesTaxRateList    TaxRateList          `json:",omitempty"`
-	MetaData            ModificationMetaData `json:",omitempty"`
+	MetaData            ModificationMetaData `json:",omitzero"`
 	Id                  string               `json:",omitempty"`
 	Name                string               `json:",omitempty"`
 	SyncToken           string               `json:",omitempty"`
@@ -17,40 +17,26 @@ type TaxCode struct {
 	Taxable             bool                 `json:",omitempty"`
 	Active              bool                 `json:",omitempty"`
 	Hidden              bool                 `json:",omitempty"`
-}
-
-// FindTaxCodes gets the full list of TaxCodes in the QuickBooks account.
-func (c *Client) FindTaxCodes(params RequestParameters) ([]TaxCode, error) {
-	var resp struct {
-		QueryResponse struct {
-			TaxCodes      []TaxCode `json:"TaxCode"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM TaxCode", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
 
-	taxCodes := make([]TaxCode, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM TaxCode ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
+	// Unknown holds the fields QuickBooks sent that TaxCode does not model.
+	Unknown UnknownFields `json:"-"`
+}
 
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
+// UnmarshalJSON decodes a TaxCode, keeping the fields it does not model in Unknown.
+func (t *TaxCode) UnmarshalJSON(data []byte) error {
+	type taxCode TaxCode
+	return unmarshalWithUnknown(data, (*taxCode)(t), &t.Unknown)
+}
 
-		taxCodes = append(taxCodes, resp.QueryResponse.TaxCodes...)
-	}
+// MarshalJSON encodes a TaxCode along with its Unknown fields.
+func (t TaxCode) MarshalJSON() ([]byte, error) {
+	type taxCode TaxCode
+	return marshalWithUnknown(taxCode(t), t.Unknown)
+}
 
-	return taxCodes, nil
+// FindTaxCodes gets the full list of TaxCodes in the QuickBooks account.
+func (c *Client) FindTaxCodes(params RequestParameters) ([]TaxCode, error) {
+	return queryAll[TaxCode](c, params, "")
 }
 
 func (c *Client) FindTaxCodesByPage(params RequestParameters, startPosition, pageSize int) ([]TaxCode, error) {
@@ -87,7 +73,7 @@ func (c *Client) FindTaxCodeById(params RequestParameters, id string) (*TaxCode,
 }
 
 // QueryTaxCodes accepts an SQL query and returns all taxCodes found using it
-func (c *Client) QueryTaxCodes(params RequestParameters, query string) ([]TaxCode, error) {
+func (c *Client) QueryTaxCodes(params RequestParameters, query QueryStatement) ([]TaxCode, error) {
 	var resp struct {
 		QueryResponse struct {
 			TaxCodes      []TaxCode `json:"TaxCode"`
@@ -96,7 +82,7 @@ func (c *Client) QueryTaxCodes(params RequestParameters, query string) ([]TaxCod
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -9,7 +9,7 @@ type TaxRate struct {
 	// EffectiveTaxRate EffectiveTaxRateData `json:",omitempty"`
 	// AgencyRef        ReferenceType        `json:",omitempty"`
 	// TaxReturnLineRef ReferenceType        `json:",omitempty"`
-	MetaData       ModificationMetaData `json:",omitempty"`
+	MetaData       ModificationMetaData `json:",omitzero"`
 	RateValue      json.Number          `json:",omitempty"`
 	Id             string               `json:",omitempty"`
 	SyncToken      string               `json:",omitempty"`
@@ -18,40 +18,26 @@ type TaxRate struct {
 	SpecialTaxType string               `json:",omitempty"`
 	DisplayType    string               `json:",omitempty"`
 	Active         bool                 `json:",omitempty"`
-}
-
-// FindTaxRates gets the full list of TaxRates in the QuickBooks account.
-func (c *Client) FindTaxRates(params RequestParameters) ([]TaxRate, error) {
-	var resp struct {
-		QueryResponse struct {
-			TaxRates      []TaxRate `json:"TaxRate"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM TaxRate", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
 
-	taxRates := make([]TaxRate, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM TaxRate ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
+	// Unknown holds the fields QuickBooks sent that TaxRate does not model.
+	Unknown UnknownFields `json:"-"`
+}
 
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
+// UnmarshalJSON decodes a TaxRate, keeping the fields it does not model in Unknown.
+func (t *TaxRate) UnmarshalJSON(data []byte) error {
+	type taxRate TaxRate
+	return unmarshalWithUnknown(data, (*taxRate)(t), &t.Unknown)
+}
 
-		taxRates = append(taxRates, resp.QueryResponse.TaxRates...)
-	}
+// MarshalJSON encodes a TaxRate along with its Unknown fields.
+func (t TaxRate) MarshalJSON() ([]byte, error) {
+	type taxRate TaxRate
+	return marshalWithUnknown(taxRate(t), t.Unknown)
+}
 
-	return taxRates, nil
+// FindTaxRates gets the full list of TaxRates in the QuickBooks account.
+func (c *Client) FindTaxRates(params RequestParameters) ([]TaxRate, error) {
+	return queryAll[TaxRate](c, params, "")
 }
 
 func (c *Client) FindTaxRatesByPage(params RequestParameters, startPosition, pageSize int) ([]TaxRate, error) {
@@ -88,7 +74,7 @@ func (c *Client) FindTaxRateById(params RequestParameters, id string) (*TaxRate,
 }
 
 // QueryTaxRates accepts an SQL query and returns all taxRates found using it
-func (c *Client) QueryTaxRates(params RequestParameters, query string) ([]TaxRate, error) {
+func (c *Client) QueryTaxRates(params RequestParameters, query QueryStatement) ([]TaxRate, error) {
 	var resp struct {
 		QueryResponse struct {
 			TaxRates      []TaxRate `json:"TaxRate"`
@@ -97,7 +83,7 @@ func (c *Client) QueryTaxRates(params RequestParameters, query string) ([]TaxRat
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
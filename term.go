@@ -7,7 +7,7 @@ import (
 )
 
 type Term struct {
-	MetaData           ModificationMetaData `json:",omitempty"`
+	MetaData           ModificationMetaData `json:",omitzero"`
 	DiscountPercent    json.Number          `json:",omitempty"`
 	DiscountDays       json.Number          `json:",omitempty"`
 	DayOfMonthDue      json.Number          `json:",omitempty"`
@@ -21,6 +21,21 @@ type Term struct {
 	Active             bool                 `json:",omitempty"`
 	Domain             string               `json:"domain,omitempty"`
 	Status             string               `json:"status,omitempty"`
+
+	// Unknown holds the fields QuickBooks sent that Term does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a Term, keeping the fields it does not model in Unknown.
+func (t *Term) UnmarshalJSON(data []byte) error {
+	type term Term
+	return unmarshalWithUnknown(data, (*term)(t), &t.Unknown)
+}
+
+// MarshalJSON encodes a Term along with its Unknown fields.
+func (t Term) MarshalJSON() ([]byte, error) {
+	type term Term
+	return marshalWithUnknown(term(t), t.Unknown)
 }
 
 // CreateTerm creates the given Term on the QuickBooks server, returning
@@ -40,36 +55,7 @@ func (c *Client) CreateTerm(params RequestParameters, term *Term) (*Term, error)
 
 // FindTerms gets the full list of Terms in the QuickBooks account.
 func (c *Client) FindTerms(params RequestParameters) ([]Term, error) {
-	var resp struct {
-		QueryResponse struct {
-			Terms         []Term `json:"Term"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Term", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	terms := make([]Term, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Term ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		terms = append(terms, resp.QueryResponse.Terms...)
-	}
-
-	return terms, nil
+	return queryAll[Term](c, params, "")
 }
 
 func (c *Client) FindTermsByPage(params RequestParameters, startPosition, pageSize int) ([]Term, error) {
@@ -106,7 +92,7 @@ func (c *Client) FindTermById(params RequestParameters, id string) (*Term, error
 }
 
 // QueryTerms accepts an SQL query and returns all terms found using it
-func (c *Client) QueryTerms(params RequestParameters, query string) ([]Term, error) {
+func (c *Client) QueryTerms(params RequestParameters, query QueryStatement) ([]Term, error) {
 	var resp struct {
 		QueryResponse struct {
 			Terms         []Term `json:"Term"`
@@ -115,7 +101,7 @@ func (c *Client) QueryTerms(params RequestParameters, query string) ([]Term, err
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -128,12 +114,14 @@ func (c *Client) UpdateTerm(params RequestParameters, term *Term) (*Term, error)
 		return nil, errors.New("missing term id")
 	}
 
-	existingTerm, err := c.FindTermById(params, term.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingTerm, err := c.FindTermById(params, term.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	term.SyncToken = existingTerm.SyncToken
+		term.SyncToken = existingTerm.SyncToken
+	}
 
 	payload := struct {
 		*Term
@@ -146,9 +134,9 @@ func (c *Client) UpdateTerm(params RequestParameters, term *Term) (*Term, error)
 		Time Date
 	}
 
-	if err = c.post(params, "term", payload, &termData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "term", payload, &termData, nil); err != nil {
+		return nil, conflictError(params, term.Id, term, err, c.FindTermById)
 	}
 
-	return &termData.Term, err
+	return &termData.Term, nil
 }
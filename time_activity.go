@@ -15,10 +15,10 @@ type TimeActivity struct {
 	ItemRef        *ReferenceType       `json:",omitempty"`
 	DepartmentRef  *ReferenceType       `json:",omitempty"`
 	PayrollItemRef *ReferenceType       `json:",omitempty"`
-	TxnDate        Date                 `json:",omitempty"`
+	TxnDate        Date                 `json:",omitzero"`
 	StartTime      *DateTime            `json:",omitempty"`
 	EndTime        *DateTime            `json:",omitempty"`
-	MetaData       ModificationMetaData `json:",omitempty"`
+	MetaData       ModificationMetaData `json:",omitzero"`
 	BillableStatus BillableStatusEnum   `json:",omitempty"`
 	BreakHours     json.Number          `json:",omitempty"`
 	BreakMinutes   json.Number          `json:",omitempty"`
@@ -34,6 +34,21 @@ type TimeActivity struct {
 	Description    string               `json:",omitempty"`
 	Taxable        bool                 `json:",omitempty"`
 	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that TimeActivity does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a TimeActivity, keeping the fields it does not model in Unknown.
+func (t *TimeActivity) UnmarshalJSON(data []byte) error {
+	type timeActivity TimeActivity
+	return unmarshalWithUnknown(data, (*timeActivity)(t), &t.Unknown)
+}
+
+// MarshalJSON encodes a TimeActivity along with its Unknown fields.
+func (t TimeActivity) MarshalJSON() ([]byte, error) {
+	type timeActivity TimeActivity
+	return marshalWithUnknown(timeActivity(t), t.Unknown)
 }
 
 // CreateTimeActivity creates the given TimeActivity on the QuickBooks server, returning
@@ -62,36 +77,7 @@ func (c *Client) DeleteTimeActivity(params RequestParameters, timeActivity *Time
 
 // FindTimeActivitys gets the full list of TimeActivitys in the QuickBooks account.
 func (c *Client) FindTimeActivities(params RequestParameters) ([]TimeActivity, error) {
-	var resp struct {
-		QueryResponse struct {
-			TimeActivities []TimeActivity `json:"TimeActivity"`
-			MaxResults     int
-			StartPosition  int
-			TotalCount     int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM TimeActivity", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	timeActivities := make([]TimeActivity, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM TimeActivity ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		timeActivities = append(timeActivities, resp.QueryResponse.TimeActivities...)
-	}
-
-	return timeActivities, nil
+	return queryAll[TimeActivity](c, params, "")
 }
 
 func (c *Client) FindTimeActivitiesByPage(params RequestParameters, startPosition, pageSize int) ([]TimeActivity, error) {
@@ -128,7 +114,7 @@ func (c *Client) FindTimeActivityById(params RequestParameters, id string) (*Tim
 }
 
 // QueryTimeActivitys accepts an SQL query and returns all timeActivitys found using it
-func (c *Client) QueryTimeActivities(params RequestParameters, query string) ([]TimeActivity, error) {
+func (c *Client) QueryTimeActivities(params RequestParameters, query QueryStatement) ([]TimeActivity, error) {
 	var resp struct {
 		QueryResponse struct {
 			TimeActivities []TimeActivity `json:"TimeActivity"`
@@ -137,7 +123,7 @@ func (c *Client) QueryTimeActivities(params RequestParameters, query string) ([]
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -150,12 +136,14 @@ func (c *Client) UpdateTimeActivity(params RequestParameters, timeActivity *Time
 		return nil, errors.New("missing time activity id")
 	}
 
-	existingTimeActivity, err := c.FindTimeActivityById(params, timeActivity.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingTimeActivity, err := c.FindTimeActivityById(params, timeActivity.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	timeActivity.SyncToken = existingTimeActivity.SyncToken
+		timeActivity.SyncToken = existingTimeActivity.SyncToken
+	}
 
 	payload := struct {
 		*TimeActivity
@@ -168,9 +156,9 @@ func (c *Client) UpdateTimeActivity(params RequestParameters, timeActivity *Time
 		Time         Date
 	}
 
-	if err = c.post(params, "timeactivity", payload, &timeActivityData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "timeactivity", payload, &timeActivityData, nil); err != nil {
+		return nil, conflictError(params, timeActivity.Id, timeActivity, err, c.FindTimeActivityById)
 	}
 
-	return &timeActivityData.TimeActivity, err
+	return &timeActivityData.TimeActivity, nil
 }
@@ -2,13 +2,16 @@ package quickbooks
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
 )
 
 type BearerToken struct {
@@ -18,16 +21,58 @@ type BearerToken struct {
 	IdToken                string      `json:"id_token"`
 	ExpiresIn              json.Number `json:"expires_in"`
 	XRefreshTokenExpiresIn json.Number `json:"x_refresh_token_expires_in"`
+	// Expiry and RefreshTokenExpiry are the absolute expiry times of the
+	// access and refresh tokens, worked out from ExpiresIn and
+	// XRefreshTokenExpiresIn when the token was received.
+	Expiry             time.Time `json:"expiry,omitzero"`
+	RefreshTokenExpiry time.Time `json:"x_refresh_token_expiry,omitzero"`
+}
+
+// SetExpiry fills in Expiry and RefreshTokenExpiry from the relative
+// lifetimes, counting from receivedAt.
+func (t *BearerToken) SetExpiry(receivedAt time.Time) {
+	if seconds, err := t.ExpiresIn.Int64(); err == nil {
+		t.Expiry = receivedAt.Add(time.Duration(seconds) * time.Second)
+	}
+	if seconds, err := t.XRefreshTokenExpiresIn.Int64(); err == nil {
+		t.RefreshTokenExpiry = receivedAt.Add(time.Duration(seconds) * time.Second)
+	}
+}
+
+// OAuth2Token converts the bearer token to an oauth2.Token. The id token, if
+// any, is available through Extra("id_token").
+func (t *BearerToken) OAuth2Token() *oauth2.Token {
+	token := &oauth2.Token{
+		AccessToken:  t.AccessToken,
+		TokenType:    t.TokenType,
+		RefreshToken: t.RefreshToken,
+		Expiry:       t.Expiry,
+	}
+	if t.IdToken != "" {
+		token = token.WithExtra(map[string]interface{}{"id_token": t.IdToken})
+	}
+	return token
 }
 
 // RefreshToken
 // Call the refresh endpoint to generate new tokens
 func (c *Client) RefreshToken(refreshToken string) (*BearerToken, error) {
+	return c.refreshToken(context.Background(), refreshToken)
+}
+
+func (c *Client) refreshToken(ctx context.Context, refreshToken string) (*BearerToken, error) {
 	urlValues := url.Values{}
 	urlValues.Set("grant_type", "refresh_token")
 	urlValues.Add("refresh_token", refreshToken)
 
-	req, err := http.NewRequest("POST", c.discoveryAPI.TokenEndpoint, bytes.NewBufferString(urlValues.Encode()))
+	receivedAt := time.Now()
+
+	discovery, err := c.DiscoveryAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", discovery.TokenEndpoint, bytes.NewBufferString(urlValues.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +94,7 @@ func (c *Client) RefreshToken(refreshToken string) (*BearerToken, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New(string(body))
+		return nil, failureFromBody(resp, body)
 	}
 
 	var token BearerToken
@@ -58,6 +103,8 @@ func (c *Client) RefreshToken(refreshToken string) (*BearerToken, error) {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	token.SetExpiry(receivedAt)
+
 	return &token, nil
 }
 
@@ -65,13 +112,24 @@ func (c *Client) RefreshToken(refreshToken string) (*BearerToken, error) {
 // Method to retrieve access token (bearer token).
 // This method can only be called once
 func (c *Client) RetrieveBearerToken(authorizationCode, redirectURI string) (*BearerToken, error) {
+	return c.retrieveBearerToken(context.Background(), authorizationCode, redirectURI)
+}
+
+func (c *Client) retrieveBearerToken(ctx context.Context, authorizationCode, redirectURI string) (*BearerToken, error) {
 	urlValues := url.Values{}
 	// set parameters
 	urlValues.Add("code", authorizationCode)
 	urlValues.Set("grant_type", "authorization_code")
 	urlValues.Add("redirect_uri", redirectURI)
 
-	req, err := http.NewRequest("POST", c.discoveryAPI.TokenEndpoint, bytes.NewBufferString(urlValues.Encode()))
+	receivedAt := time.Now()
+
+	discovery, err := c.DiscoveryAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", discovery.TokenEndpoint, bytes.NewBufferString(urlValues.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +151,7 @@ func (c *Client) RetrieveBearerToken(authorizationCode, redirectURI string) (*Be
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, parseFailure(resp)
+		return nil, failureFromBody(resp, body)
 	}
 
 	var token BearerToken
@@ -102,16 +160,34 @@ func (c *Client) RetrieveBearerToken(authorizationCode, redirectURI string) (*Be
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	token.SetExpiry(receivedAt)
+
 	return &token, nil
 }
 
 // RevokeToken
-// Call the revoke endpoint to revoke tokens
+// Call the revoke endpoint to revoke tokens. Tokens holding the revoked
+// refresh token are deleted from the TokenManager's store.
 func (c *Client) RevokeToken(refreshToken string) error {
+	ctx := context.Background()
+
+	if err := c.revokeToken(ctx, refreshToken); err != nil {
+		return err
+	}
+
+	return c.tokens.forget(ctx, refreshToken)
+}
+
+func (c *Client) revokeToken(ctx context.Context, refreshToken string) error {
 	urlValues := url.Values{}
 	urlValues.Add("token", refreshToken)
 
-	req, err := http.NewRequest("POST", c.discoveryAPI.RevocationEndpoint, bytes.NewBufferString(urlValues.Encode()))
+	discovery, err := c.DiscoveryAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", discovery.RevocationEndpoint, bytes.NewBufferString(urlValues.Encode()))
 	if err != nil {
 		return err
 	}
@@ -133,11 +209,9 @@ func (c *Client) RevokeToken(refreshToken string) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return errors.New(string(body))
+		return failureFromBody(resp, body)
 	}
 
-	c.Client = nil
-
 	return nil
 }
 
@@ -0,0 +1,295 @@
+package quickbooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultTokenRefreshWindow is how long before the access token expires the
+// TokenManager refreshes it.
+const DefaultTokenRefreshWindow = 5 * time.Minute
+
+// ErrTokenNotFound is returned when no token is known for a realm.
+var ErrTokenNotFound = errors.New("no token found for realm")
+
+// TokenRotatedFunc is called after a refresh returned a refresh token that
+// differs from the one used to request it. It runs before the new token is
+// saved, so the token survives a failing TokenStore.
+type TokenRotatedFunc func(ctx context.Context, realmId string, token *BearerToken)
+
+// TokenManager keeps the bearer token of every realm the client talks to and
+// refreshes it through Client.RefreshToken shortly before the access token
+// expires. Concurrent callers share a single refresh per realm.
+//
+// Tokens are persisted in a TokenStore and cached in memory. Requests made
+// with a nil RequestParameters.Token get their token from the client's
+// TokenManager.
+type TokenManager struct {
+	client        *Client
+	store         TokenStore
+	onRotated     TokenRotatedFunc
+	refreshWindow time.Duration
+	group         singleflight.Group
+
+	mu     sync.Mutex
+	tokens map[string]*BearerToken
+}
+
+func newTokenManager(client *Client, store TokenStore, onRotated TokenRotatedFunc, refreshWindow time.Duration) *TokenManager {
+	if store == nil {
+		store = NewMemoryTokenStore()
+	}
+	if refreshWindow <= 0 {
+		refreshWindow = DefaultTokenRefreshWindow
+	}
+
+	return &TokenManager{
+		client:        client,
+		store:         store,
+		onRotated:     onRotated,
+		refreshWindow: refreshWindow,
+		tokens:        make(map[string]*BearerToken),
+	}
+}
+
+// TokenManager returns the client's TokenManager.
+func (c *Client) TokenManager() *TokenManager {
+	return c.tokens
+}
+
+// RetrieveBearerToken exchanges the authorization code for a token and
+// saves it for the realm.
+func (m *TokenManager) RetrieveBearerToken(ctx context.Context, realmId, authorizationCode, redirectURI string) (*BearerToken, error) {
+	token, err := m.client.retrieveBearerToken(ctx, authorizationCode, redirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.SetToken(ctx, realmId, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// SetToken saves the token for the realm. Tokens handed to the manager
+// without an absolute expiry are assumed to be fresh.
+func (m *TokenManager) SetToken(ctx context.Context, realmId string, token *BearerToken) error {
+	t := *token
+	if t.Expiry.IsZero() {
+		t.SetExpiry(time.Now())
+	}
+
+	if err := m.store.Save(ctx, realmId, &t); err != nil {
+		return fmt.Errorf("failed to save token for realm %s: %w", realmId, err)
+	}
+
+	m.cache(realmId, &t)
+
+	return nil
+}
+
+// RemoveToken deletes the token for the realm.
+func (m *TokenManager) RemoveToken(ctx context.Context, realmId string) error {
+	m.mu.Lock()
+	delete(m.tokens, realmId)
+	m.mu.Unlock()
+
+	if err := m.store.Delete(ctx, realmId); err != nil {
+		return fmt.Errorf("failed to delete token for realm %s: %w", realmId, err)
+	}
+
+	return nil
+}
+
+// Realms lists the realms with a stored token.
+func (m *TokenManager) Realms(ctx context.Context) ([]string, error) {
+	return m.store.List(ctx)
+}
+
+// Revoke revokes the realm's refresh token and deletes the stored token.
+func (m *TokenManager) Revoke(ctx context.Context, realmId string) error {
+	token, err := m.load(ctx, realmId)
+	if err != nil {
+		return err
+	}
+
+	if err = m.client.revokeToken(ctx, token.RefreshToken); err != nil {
+		return err
+	}
+
+	return m.RemoveToken(ctx, realmId)
+}
+
+// Token returns a valid token for the realm, refreshing it first if the
+// access token expires within the refresh window.
+func (m *TokenManager) Token(ctx context.Context, realmId string) (*BearerToken, error) {
+	token, err := m.load(ctx, realmId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.needsRefresh(token) {
+		return token, nil
+	}
+
+	return m.refresh(ctx, realmId, false)
+}
+
+// Refresh refreshes the token for the realm regardless of its expiry.
+func (m *TokenManager) Refresh(ctx context.Context, realmId string) (*BearerToken, error) {
+	return m.refresh(ctx, realmId, true)
+}
+
+// TokenSource returns an oauth2.TokenSource for the realm backed by the
+// manager. ctx is used for the refresh requests.
+func (m *TokenManager) TokenSource(ctx context.Context, realmId string) oauth2.TokenSource {
+	return &realmTokenSource{ctx: ctx, manager: m, realmId: realmId}
+}
+
+// load returns a copy of the realm's token, reading through to the store
+// on a cache miss.
+func (m *TokenManager) load(ctx context.Context, realmId string) (*BearerToken, error) {
+	m.mu.Lock()
+	token, ok := m.tokens[realmId]
+	m.mu.Unlock()
+
+	if ok {
+		t := *token
+		return &t, nil
+	}
+
+	token, err := m.store.Load(ctx, realmId)
+	if err != nil {
+		return nil, err
+	}
+
+	m.cache(realmId, token)
+
+	t := *token
+	return &t, nil
+}
+
+func (m *TokenManager) cache(realmId string, token *BearerToken) {
+	t := *token
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[realmId] = &t
+}
+
+func (m *TokenManager) needsRefresh(token *BearerToken) bool {
+	return token.Expiry.IsZero() || time.Until(token.Expiry) < m.refreshWindow
+}
+
+func (m *TokenManager) refresh(ctx context.Context, realmId string, force bool) (*BearerToken, error) {
+	// The refresh is shared by every waiting caller, so it must not be cut
+	// short when the caller that started it gives up.
+	ch := m.group.DoChan(realmId, func() (interface{}, error) {
+		ctx := context.WithoutCancel(ctx)
+
+		// Go back to the store, another process sharing it may already
+		// have rotated the refresh token.
+		token, err := m.store.Load(ctx, realmId)
+		if err != nil {
+			return nil, err
+		}
+		m.cache(realmId, token)
+
+		// Another caller may have refreshed while this one was waiting.
+		if !force && !m.needsRefresh(token) {
+			return token, nil
+		}
+
+		refreshed, err := m.client.refreshToken(ctx, token.RefreshToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to refresh token for realm %s: %w", realmId, err)
+		}
+
+		m.cache(realmId, refreshed)
+
+		if m.onRotated != nil && refreshed.RefreshToken != token.RefreshToken {
+			t := *refreshed
+			m.onRotated(ctx, realmId, &t)
+		}
+
+		if err = m.store.Save(ctx, realmId, refreshed); err != nil {
+			return nil, fmt.Errorf("failed to save refreshed token for realm %s: %w", realmId, err)
+		}
+
+		return refreshed, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		t := *res.Val.(*BearerToken)
+		return &t, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// forget deletes every stored token holding the given refresh token.
+func (m *TokenManager) forget(ctx context.Context, refreshToken string) error {
+	realmIds, err := m.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, realmId := range realmIds {
+		token, err := m.load(ctx, realmId)
+		if err != nil {
+			if errors.Is(err, ErrTokenNotFound) {
+				continue
+			}
+			return err
+		}
+
+		if token.RefreshToken == refreshToken {
+			if err = m.RemoveToken(ctx, realmId); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// accessToken returns the access token to send with a request, taking it
+// from the TokenManager when params.Token is nil.
+func (c *Client) accessToken(params RequestParameters) (string, error) {
+	if params.Token != nil {
+		return params.Token.AccessToken, nil
+	}
+
+	token, err := c.tokens.Token(params.Ctx, params.RealmId)
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+type realmTokenSource struct {
+	ctx     context.Context
+	manager *TokenManager
+	realmId string
+}
+
+func (s *realmTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.manager.Token(s.ctx, s.realmId)
+	if err != nil {
+		return nil, err
+	}
+
+	return token.OAuth2Token(), nil
+}
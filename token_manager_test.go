@@ -0,0 +1,95 @@
+package quickbooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTokenTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientRequest{
+		Client:       server.Client(),
+		DiscoveryAPI: &DiscoveryAPI{TokenEndpoint: server.URL + "/token"},
+		ClientId:     "id",
+		ClientSecret: "secret",
+		Endpoint:     server.URL,
+	})
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestTokenManagerRefreshesOnce(t *testing.T) {
+	var refreshes atomic.Int32
+	client := newTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "old-refresh", r.PostForm.Get("refresh_token"))
+
+		n := refreshes.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprintf(w, `{"access_token":"access-%d","refresh_token":"new-refresh","expires_in":3600,"x_refresh_token_expires_in":8726400}`, n)
+	})
+
+	manager := client.TokenManager()
+	require.NoError(t, manager.SetToken(context.Background(), "1234", &BearerToken{
+		AccessToken:  "expiring",
+		RefreshToken: "old-refresh",
+		Expiry:       time.Now().Add(time.Minute),
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := manager.Token(context.Background(), "1234")
+			assert.NoError(t, err)
+			assert.Equal(t, "access-1", token.AccessToken)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), refreshes.Load())
+
+	token, err := manager.Token(context.Background(), "1234")
+	require.NoError(t, err)
+	assert.Equal(t, "new-refresh", token.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, 5*time.Second)
+	assert.WithinDuration(t, time.Now().Add(8726400*time.Second), token.RefreshTokenExpiry, 5*time.Second)
+	assert.Equal(t, int32(1), refreshes.Load())
+}
+
+func TestTokenManagerAuthorizesRequests(t *testing.T) {
+	client := newTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer fresh", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"CompanyInfo":{"CompanyName":"Craig's Design"}}`))
+	})
+
+	params := RequestParameters{Ctx: context.Background(), RealmId: "1234", WaitOnRateLimit: true}
+
+	_, err := client.FindCompanyInfo(params)
+	require.ErrorIs(t, err, ErrTokenNotFound)
+
+	require.NoError(t, client.TokenManager().SetToken(context.Background(), "1234", &BearerToken{AccessToken: "fresh", ExpiresIn: "3600"}))
+
+	info, err := client.FindCompanyInfo(params)
+	require.NoError(t, err)
+	assert.Equal(t, "Craig's Design", info.CompanyName)
+
+	oauthToken, err := client.TokenManager().TokenSource(context.Background(), "1234").Token()
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", oauthToken.AccessToken)
+	assert.True(t, oauthToken.Valid())
+}
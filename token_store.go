@@ -0,0 +1,247 @@
+package quickbooks
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// TokenStore persists bearer tokens by realm id. Load returns an error
+// wrapping ErrTokenNotFound when the realm has no token.
+//
+// Implementations must be safe for concurrent use.
+type TokenStore interface {
+	Load(ctx context.Context, realmId string) (*BearerToken, error)
+	Save(ctx context.Context, realmId string, token *BearerToken) error
+	Delete(ctx context.Context, realmId string) error
+	List(ctx context.Context) ([]string, error)
+}
+
+// MemoryTokenStore keeps tokens in memory. It is the default store of the
+// TokenManager.
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]BearerToken
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]BearerToken)}
+}
+
+func (s *MemoryTokenStore) Load(ctx context.Context, realmId string) (*BearerToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[realmId]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, realmId)
+	}
+
+	return &token, nil
+}
+
+func (s *MemoryTokenStore) Save(ctx context.Context, realmId string, token *BearerToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[realmId] = *token
+	return nil
+}
+
+func (s *MemoryTokenStore) Delete(ctx context.Context, realmId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tokens, realmId)
+	return nil
+}
+
+func (s *MemoryTokenStore) List(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	realmIds := make([]string, 0, len(s.tokens))
+	for realmId := range s.tokens {
+		realmIds = append(realmIds, realmId)
+	}
+	sort.Strings(realmIds)
+
+	return realmIds, nil
+}
+
+// FileTokenStore keeps tokens in a JSON file, each one encrypted with
+// AES-GCM and bound to its realm id. The file is rewritten atomically on
+// every change.
+type FileTokenStore struct {
+	path string
+	aead cipher.AEAD
+	mu   sync.Mutex
+}
+
+// NewFileTokenStore returns a store backed by the file at path. The key must
+// be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+func NewFileTokenStore(path string, key []byte) (*FileTokenStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token store key: %v", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token store cipher: %v", err)
+	}
+
+	return &FileTokenStore{path: path, aead: aead}, nil
+}
+
+func (s *FileTokenStore) Load(ctx context.Context, realmId string) (*BearerToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, ok := entries[realmId]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, realmId)
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, fmt.Errorf("corrupt token entry for realm %s", realmId)
+	}
+
+	plaintext, err := s.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(realmId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt token for realm %s: %v", realmId, err)
+	}
+
+	var token BearerToken
+	if err = json.Unmarshal(plaintext, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token for realm %s: %v", realmId, err)
+	}
+
+	return &token, nil
+}
+
+func (s *FileTokenStore) Save(ctx context.Context, realmId string, token *BearerToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %v", err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	entries[realmId] = s.aead.Seal(nonce, nonce, plaintext, []byte(realmId))
+
+	return s.write(entries)
+}
+
+func (s *FileTokenStore) Delete(ctx context.Context, realmId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := entries[realmId]; !ok {
+		return nil
+	}
+	delete(entries, realmId)
+
+	return s.write(entries)
+}
+
+func (s *FileTokenStore) List(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	realmIds := make([]string, 0, len(entries))
+	for realmId := range entries {
+		realmIds = append(realmIds, realmId)
+	}
+	sort.Strings(realmIds)
+
+	return realmIds, nil
+}
+
+// read loads the sealed entries. A missing file is an empty store.
+func (s *FileTokenStore) read() (map[string][]byte, error) {
+	entries := make(map[string][]byte)
+
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token store: %v", err)
+	}
+
+	if err = json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token store: %v", err)
+	}
+
+	return entries, nil
+}
+
+// write replaces the file through a temporary file so a crash never leaves
+// a half-written store behind.
+func (s *FileTokenStore) write(entries map[string][]byte) error {
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal token store: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create token store: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write token store: %v", err)
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write token store: %v", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write token store: %v", err)
+	}
+
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace token store: %v", err)
+	}
+
+	return nil
+}
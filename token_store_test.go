@@ -0,0 +1,90 @@
+package quickbooks
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	key := bytes.Repeat([]byte{7}, 32)
+
+	store, err := NewFileTokenStore(path, key)
+	require.NoError(t, err)
+
+	_, err = store.Load(ctx, "1234")
+	require.ErrorIs(t, err, ErrTokenNotFound)
+
+	token := &BearerToken{AccessToken: "access", RefreshToken: "secret-refresh", Expiry: time.Now().Round(time.Second)}
+	require.NoError(t, store.Save(ctx, "1234", token))
+	require.NoError(t, store.Save(ctx, "5678", token))
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "secret-refresh")
+
+	reopened, err := NewFileTokenStore(path, key)
+	require.NoError(t, err)
+
+	loaded, err := reopened.Load(ctx, "1234")
+	require.NoError(t, err)
+	assert.Equal(t, "secret-refresh", loaded.RefreshToken)
+	assert.True(t, token.Expiry.Equal(loaded.Expiry))
+
+	realmIds, err := reopened.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1234", "5678"}, realmIds)
+
+	wrongKey, err := NewFileTokenStore(path, bytes.Repeat([]byte{8}, 32))
+	require.NoError(t, err)
+	_, err = wrongKey.Load(ctx, "1234")
+	assert.Error(t, err)
+
+	require.NoError(t, reopened.Delete(ctx, "1234"))
+	_, err = store.Load(ctx, "1234")
+	require.ErrorIs(t, err, ErrTokenNotFound)
+}
+
+func TestTokenManagerRotationAndRevocation(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+	var rotated []string
+
+	client := newTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/revoke") {
+			return
+		}
+		w.Write([]byte(`{"access_token":"new-access","refresh_token":"rotated","expires_in":3600}`))
+	})
+	client.discovery.discovery.RevocationEndpoint = client.discovery.discovery.TokenEndpoint + "/revoke"
+	client.tokens = newTokenManager(client, store, func(ctx context.Context, realmId string, token *BearerToken) {
+		rotated = append(rotated, realmId+":"+token.RefreshToken)
+	}, 0)
+
+	require.NoError(t, store.Save(ctx, "1234", &BearerToken{AccessToken: "old", RefreshToken: "original"}))
+
+	token, err := client.TokenManager().Token(ctx, "1234")
+	require.NoError(t, err)
+	assert.Equal(t, "new-access", token.AccessToken)
+	assert.Equal(t, []string{"1234:rotated"}, rotated)
+
+	saved, err := store.Load(ctx, "1234")
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", saved.RefreshToken)
+
+	require.NoError(t, client.RevokeToken("rotated"))
+	_, err = store.Load(ctx, "1234")
+	require.ErrorIs(t, err, ErrTokenNotFound)
+	_, err = client.TokenManager().Token(ctx, "1234")
+	require.ErrorIs(t, err, ErrTokenNotFound)
+}
@@ -0,0 +1,214 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Transfer represents a QuickBooks Transfer object, moving Amount from
+// FromAccountRef to ToAccountRef. ExchangeRate converts between the
+// accounts' currencies when they differ.
+type Transfer struct {
+	FromAccountRef ReferenceType
+	ToAccountRef   ReferenceType
+	RecurDataRef   *ReferenceType       `json:",omitempty"`
+	TxnDate        *Date                `json:",omitempty"`
+	MetaData       ModificationMetaData `json:",omitzero"`
+	Amount         json.Number
+	ExchangeRate   json.Number `json:",omitempty"`
+	Id             string      `json:",omitempty"`
+	SyncToken      string      `json:",omitempty"`
+	PrivateNote    string      `json:",omitempty"`
+	TxnSource      string      `json:",omitempty"`
+	Domain         string      `json:"domain,omitempty"`
+	Status         string      `json:"status,omitempty"`
+	// TransactionLocationType
+
+	// Unknown holds the fields QuickBooks sent that Transfer does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a Transfer, keeping the fields it does not model in Unknown.
+func (t *Transfer) UnmarshalJSON(data []byte) error {
+	type transfer Transfer
+	return unmarshalWithUnknown(data, (*transfer)(t), &t.Unknown)
+}
+
+// MarshalJSON encodes a Transfer along with its Unknown fields.
+func (t Transfer) MarshalJSON() ([]byte, error) {
+	type transfer Transfer
+	return marshalWithUnknown(transfer(t), t.Unknown)
+}
+
+// validateTransfer checks that both accounts of the transfer are Bank or
+// Other Current Asset accounts, and that they share a currency unless the
+// transfer has an ExchangeRate. Accounts without a CurrencyRef are taken to
+// be in the same currency as any other.
+func (c *Client) validateTransfer(params RequestParameters, transfer *Transfer) error {
+	from, to := transfer.FromAccountRef.Value, transfer.ToAccountRef.Value
+	if from == "" || to == "" {
+		return errors.New("missing from/to account")
+	}
+	if from == to {
+		return fmt.Errorf("cannot transfer from account %s to itself", from)
+	}
+
+	accounts, err := c.QueryAccounts(params, RawQuery("SELECT * FROM Account WHERE Id IN ("+quoteQueryString(from)+", "+quoteQueryString(to)+")"))
+	if err != nil {
+		return fmt.Errorf("failed to look up transfer accounts: %w", err)
+	}
+
+	byId := make(map[string]*Account, len(accounts))
+	for i := range accounts {
+		byId[accounts[i].Id] = &accounts[i]
+	}
+
+	for _, id := range []string{from, to} {
+		account, ok := byId[id]
+		if !ok {
+			return fmt.Errorf("transfer account %s not found", id)
+		}
+		if account.AccountType != BankAccountType && account.AccountType != OtherCurrentAssetAccountType {
+			return fmt.Errorf("cannot transfer with %s account %s", account.AccountType, id)
+		}
+	}
+
+	fromCurrency, toCurrency := accountCurrency(byId[from]), accountCurrency(byId[to])
+	if fromCurrency != "" && toCurrency != "" && fromCurrency != toCurrency && transfer.ExchangeRate == "" {
+		return fmt.Errorf("transfer from %s to %s needs an exchange rate", fromCurrency, toCurrency)
+	}
+
+	return nil
+}
+
+// accountCurrency returns the currency code of the account, or "" when
+// QuickBooks leaves CurrencyRef out, as it does for companies without
+// multicurrency, whose accounts all share the home currency.
+func accountCurrency(account *Account) string {
+	if account.CurrencyRef == nil {
+		return ""
+	}
+	return account.CurrencyRef.Value
+}
+
+// CreateTransfer validates the given Transfer and creates it on the
+// QuickBooks server, returning the resulting Transfer object.
+func (c *Client) CreateTransfer(params RequestParameters, transfer *Transfer) (*Transfer, error) {
+	if err := c.validateTransfer(params, transfer); err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Transfer Transfer
+		Time     Date
+	}
+
+	if err := c.post(params, "transfer", transfer, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return &resp.Transfer, nil
+}
+
+// DeleteTransfer deletes the transfer.
+func (c *Client) DeleteTransfer(params RequestParameters, transfer *Transfer) error {
+	if transfer.Id == "" || transfer.SyncToken == "" {
+		return errors.New("missing id/sync token")
+	}
+
+	return c.post(params, "transfer", transfer, nil, map[string]string{"operation": "delete"})
+}
+
+// FindTransfers gets the full list of Transfers in the QuickBooks account.
+func (c *Client) FindTransfers(params RequestParameters) ([]Transfer, error) {
+	return queryAll[Transfer](c, params, "")
+}
+
+func (c *Client) FindTransfersByPage(params RequestParameters, startPosition, pageSize int) ([]Transfer, error) {
+	var resp struct {
+		QueryResponse struct {
+			Transfers     []Transfer `json:"Transfer"`
+			MaxResults    int
+			StartPosition int
+			TotalCount    int
+		}
+	}
+
+	query := "SELECT * FROM Transfer ORDERBY Id STARTPOSITION " + strconv.Itoa(startPosition) + " MAXRESULTS " + strconv.Itoa(pageSize)
+
+	if err := c.query(params, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QueryResponse.Transfers, nil
+}
+
+// FindTransferById finds the transfer by the given id
+func (c *Client) FindTransferById(params RequestParameters, id string) (*Transfer, error) {
+	var resp struct {
+		Transfer Transfer
+		Time     Date
+	}
+
+	if err := c.get(params, "transfer/"+id, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return &resp.Transfer, nil
+}
+
+// QueryTransfers accepts an SQL query and returns all transfers found using it
+func (c *Client) QueryTransfers(params RequestParameters, query QueryStatement) ([]Transfer, error) {
+	var resp struct {
+		QueryResponse struct {
+			Transfers     []Transfer `json:"Transfer"`
+			StartPosition int
+			MaxResults    int
+		}
+	}
+
+	if err := c.queryStatement(params, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QueryResponse.Transfers, nil
+}
+
+// UpdateTransfer validates the transfer and full updates it, meaning that missing writable fields will be set to nil/null
+func (c *Client) UpdateTransfer(params RequestParameters, transfer *Transfer) (*Transfer, error) {
+	if transfer.Id == "" {
+		return nil, errors.New("missing transfer id")
+	}
+
+	if err := c.validateTransfer(params, transfer); err != nil {
+		return nil, err
+	}
+
+	if !params.StrictConcurrency {
+		existingTransfer, err := c.FindTransferById(params, transfer.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		transfer.SyncToken = existingTransfer.SyncToken
+	}
+
+	payload := struct {
+		*Transfer
+	}{
+		Transfer: transfer,
+	}
+
+	var transferData struct {
+		Transfer Transfer
+		Time     Date
+	}
+
+	if err := c.post(params, "transfer", payload, &transferData, nil); err != nil {
+		return nil, conflictError(params, transfer.Id, transfer, err, c.FindTransferById)
+	}
+
+	return &transferData.Transfer, nil
+}
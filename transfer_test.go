@@ -0,0 +1,84 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransferChecksAccounts(t *testing.T) {
+	var created Transfer
+	client, params := newRetryTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/company/1234/query":
+			w.Write([]byte(`{"QueryResponse":{"Account":[
+				{"Id":"35","AccountType":"Bank","CurrencyRef":{"value":"USD"}},
+				{"Id":"36","AccountType":"Other Current Asset","CurrencyRef":{"value":"USD"}},
+				{"Id":"37","AccountType":"Bank","CurrencyRef":{"value":"EUR"}},
+				{"Id":"38","AccountType":"Bank"},
+				{"Id":"79","AccountType":"Income","CurrencyRef":{"value":"USD"}}
+			]}}`))
+		case "/v3/company/1234/transfer":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			created.Id = "90"
+			json.NewEncoder(w).Encode(map[string]interface{}{"Transfer": created})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	txnDate := NewDate(2024, 5, 31)
+	transfer, err := client.CreateTransfer(params, &Transfer{
+		FromAccountRef: ReferenceType{Value: "35"},
+		ToAccountRef:   ReferenceType{Value: "36"},
+		Amount:         "1500.00",
+		TxnDate:        &txnDate,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "90", transfer.Id)
+	assert.Equal(t, json.Number("1500.00"), created.Amount)
+	assert.Equal(t, "2024-05-31", created.TxnDate.Format("2006-01-02"))
+
+	_, err = client.CreateTransfer(params, &Transfer{
+		FromAccountRef: ReferenceType{Value: "35"},
+		ToAccountRef:   ReferenceType{Value: "79"},
+		Amount:         "10",
+	})
+	assert.EqualError(t, err, "cannot transfer with Income account 79")
+
+	_, err = client.CreateTransfer(params, &Transfer{
+		FromAccountRef: ReferenceType{Value: "35"},
+		ToAccountRef:   ReferenceType{Value: "37"},
+		Amount:         "10",
+	})
+	assert.EqualError(t, err, "transfer from USD to EUR needs an exchange rate")
+
+	_, err = client.CreateTransfer(params, &Transfer{
+		FromAccountRef: ReferenceType{Value: "35"},
+		ToAccountRef:   ReferenceType{Value: "37"},
+		Amount:         "10",
+		ExchangeRate:   "1.08",
+	})
+	assert.NoError(t, err)
+
+	// An account without a CurrencyRef is in the same currency as any other.
+	_, err = client.CreateTransfer(params, &Transfer{
+		FromAccountRef: ReferenceType{Value: "38"},
+		ToAccountRef:   ReferenceType{Value: "37"},
+		Amount:         "10",
+	})
+	assert.NoError(t, err)
+
+	_, err = client.ExecuteBatch(params, []BatchItemRequest{BatchCreate("1", &Transfer{
+		FromAccountRef: ReferenceType{Value: "35"},
+		ToAccountRef:   ReferenceType{Value: "79"},
+		Amount:         "10",
+	})})
+	assert.EqualError(t, err, "batch item 1: cannot transfer with Income account 79")
+
+	_, err = client.CreateTransfer(params, &Transfer{FromAccountRef: ReferenceType{Value: "35"}, ToAccountRef: ReferenceType{Value: "35"}})
+	assert.EqualError(t, err, "cannot transfer from account 35 to itself")
+}
@@ -0,0 +1,166 @@
+package quickbooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// UnknownFields holds the JSON fields QuickBooks sent that an entity does not
+// model, keyed by field name. They are written back when the entity is
+// marshalled, so a full update does not clear them.
+type UnknownFields map[string]json.RawMessage
+
+// Get decodes the field named key into v. It reports whether the field is
+// present.
+func (u UnknownFields) Get(key string, v interface{}) (bool, error) {
+	raw, ok := u[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+// knownFieldsCache maps struct types to the lowercased JSON names of their
+// fields.
+var knownFieldsCache sync.Map
+
+// knownFields returns the lowercased JSON names encoding/json decodes into t,
+// which must be a struct type. Names are lowercased because encoding/json
+// matches them case-insensitively.
+func knownFields(t reflect.Type) map[string]bool {
+	if fields, ok := knownFieldsCache.Load(t); ok {
+		return fields.(map[string]bool)
+	}
+
+	fields := make(map[string]bool)
+	addKnownFields(t, fields)
+
+	knownFieldsCache.Store(t, fields)
+	return fields
+}
+
+func addKnownFields(t reflect.Type, fields map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addKnownFields(embedded, fields)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = true
+	}
+}
+
+// unmarshalWithUnknown decodes data into v, a pointer to a struct, and stores
+// the fields v does not know in unknown.
+func unmarshalWithUnknown(data []byte, v interface{}, unknown *UnknownFields) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+
+	known := knownFields(reflect.TypeOf(v).Elem())
+	*unknown = nil
+	for key, value := range object {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		if *unknown == nil {
+			*unknown = make(UnknownFields)
+		}
+		(*unknown)[key] = value
+	}
+
+	return nil
+}
+
+// marshalWithUnknown encodes v, a struct, followed by the unknown fields.
+// Unknown fields that v models are left out, so they never override it.
+func marshalWithUnknown(v interface{}, unknown UnknownFields) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(unknown) == 0 {
+		return data, err
+	}
+
+	known := knownFields(reflect.TypeOf(v))
+	keys := make([]string, 0, len(unknown))
+	for key := range unknown {
+		if !known[strings.ToLower(key)] {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return data, nil
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, key := range keys {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(unknown[key])
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// sparsePayload is the body of a sparse update of entity.
+type sparsePayload struct {
+	entity interface{}
+}
+
+// MarshalJSON encodes the entity with the sparse flag set.
+func (p sparsePayload) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(p.entity)
+	if err != nil {
+		return nil, err
+	}
+	return markSparse(data)
+}
+
+// markSparse sets the sparse flag of an encoded entity.
+func markSparse(data []byte) ([]byte, error) {
+	var entity map[string]json.RawMessage
+	if err := json.Unmarshal(data, &entity); err != nil {
+		return nil, err
+	}
+	entity["sparse"] = json.RawMessage("true")
+
+	return json.Marshal(entity)
+}
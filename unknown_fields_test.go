@@ -0,0 +1,67 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownFieldsRoundTrip(t *testing.T) {
+	data := []byte(`{
+		"Id": "130",
+		"SyncToken": "2",
+		"DocNumber": "1037",
+		"GlobalTaxCalculation": "TaxExcluded",
+		"InvoiceLink": "https://example.com/invoice/130",
+		"TransactionLocationType": {"value": "WithinFrance"}
+	}`)
+
+	var invoice Invoice
+	require.NoError(t, json.Unmarshal(data, &invoice))
+	assert.Equal(t, "1037", invoice.DocNumber)
+	assert.Len(t, invoice.Unknown, 3)
+
+	var calculation string
+	ok, err := invoice.Unknown.Get("GlobalTaxCalculation", &calculation)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "TaxExcluded", calculation)
+
+	ok, err = invoice.Unknown.Get("DocNumber", &calculation)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	invoice.DocNumber = "1038"
+	// Unknown fields never override modeled ones.
+	invoice.Unknown["docnumber"] = json.RawMessage(`"stale"`)
+
+	encoded, err := json.Marshal(invoice)
+	require.NoError(t, err)
+
+	var object map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(encoded, &object))
+	assert.JSONEq(t, `"1038"`, string(object["DocNumber"]))
+	assert.NotContains(t, object, "docnumber")
+	assert.JSONEq(t, `"TaxExcluded"`, string(object["GlobalTaxCalculation"]))
+	assert.JSONEq(t, `"https://example.com/invoice/130"`, string(object["InvoiceLink"]))
+	assert.JSONEq(t, `{"value": "WithinFrance"}`, string(object["TransactionLocationType"]))
+}
+
+func TestSparsePayload(t *testing.T) {
+	customer := &Customer{
+		Id:      "58",
+		Notes:   "Pays late",
+		Unknown: UnknownFields{"GSTIN": json.RawMessage(`"22AAAAA0000A1Z5"`)},
+	}
+
+	encoded, err := json.Marshal(sparsePayload{customer})
+	require.NoError(t, err)
+
+	var object map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(encoded, &object))
+	assert.JSONEq(t, `true`, string(object["sparse"]))
+	assert.JSONEq(t, `"Pays late"`, string(object["Notes"]))
+	assert.JSONEq(t, `"22AAAAA0000A1Z5"`, string(object["GSTIN"]))
+}
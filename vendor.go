@@ -18,7 +18,7 @@ type Vendor struct {
 	WebAddr             *WebSiteAddress      `json:",omitempty"`
 	BillAddr            *PhysicalAddress     `json:",omitempty"`
 	OtherContactInfo    *ContactInfo         `json:",omitempty"`
-	MetaData            ModificationMetaData `json:",omitempty"`
+	MetaData            ModificationMetaData `json:",omitzero"`
 	CostRate            json.Number          `json:",omitempty"`
 	BillRate            json.Number          `json:",omitempty"`
 	Balance             json.Number          `json:",omitempty"`
@@ -49,6 +49,21 @@ type Vendor struct {
 	// HasTPAR
 	// TaxReportingBasis
 	// VendorPaymentBankDetail
+
+	// Unknown holds the fields QuickBooks sent that Vendor does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a Vendor, keeping the fields it does not model in Unknown.
+func (v *Vendor) UnmarshalJSON(data []byte) error {
+	type vendor Vendor
+	return unmarshalWithUnknown(data, (*vendor)(v), &v.Unknown)
+}
+
+// MarshalJSON encodes a Vendor along with its Unknown fields.
+func (v Vendor) MarshalJSON() ([]byte, error) {
+	type vendor Vendor
+	return marshalWithUnknown(vendor(v), v.Unknown)
 }
 
 // CreateVendor creates the given Vendor on the QuickBooks server, returning
@@ -68,36 +83,7 @@ func (c *Client) CreateVendor(params RequestParameters, vendor *Vendor) (*Vendor
 
 // FindVendors gets the full list of Vendors in the QuickBooks account.
 func (c *Client) FindVendors(params RequestParameters) ([]Vendor, error) {
-	var resp struct {
-		QueryResponse struct {
-			Vendors       []Vendor `json:"Vendor"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM Vendor", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	vendors := make([]Vendor, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM Vendor ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		vendors = append(vendors, resp.QueryResponse.Vendors...)
-	}
-
-	return vendors, nil
+	return queryAll[Vendor](c, params, "")
 }
 
 func (c *Client) FindVendorsByPage(params RequestParameters, startPosition, pageSize int) ([]Vendor, error) {
@@ -134,7 +120,7 @@ func (c *Client) FindVendorById(params RequestParameters, id string) (*Vendor, e
 }
 
 // QueryVendors accepts an SQL query and returns all vendors found using it
-func (c *Client) QueryVendors(params RequestParameters, query string) ([]Vendor, error) {
+func (c *Client) QueryVendors(params RequestParameters, query QueryStatement) ([]Vendor, error) {
 	var resp struct {
 		QueryResponse struct {
 			Vendors       []Vendor `json:"Vendor"`
@@ -143,7 +129,7 @@ func (c *Client) QueryVendors(params RequestParameters, query string) ([]Vendor,
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -156,12 +142,14 @@ func (c *Client) UpdateVendor(params RequestParameters, vendor *Vendor) (*Vendor
 		return nil, errors.New("missing vendor id")
 	}
 
-	existingVendor, err := c.FindVendorById(params, vendor.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingVendor, err := c.FindVendorById(params, vendor.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	vendor.SyncToken = existingVendor.SyncToken
+		vendor.SyncToken = existingVendor.SyncToken
+	}
 
 	payload := struct {
 		*Vendor
@@ -174,9 +162,9 @@ func (c *Client) UpdateVendor(params RequestParameters, vendor *Vendor) (*Vendor
 		Time   Date
 	}
 
-	if err = c.post(params, "vendor", payload, &vendorData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "vendor", payload, &vendorData, nil); err != nil {
+		return nil, conflictError(params, vendor.Id, vendor, err, c.FindVendorById)
 	}
 
-	return &vendorData.Vendor, err
+	return &vendorData.Vendor, nil
 }
@@ -15,7 +15,7 @@ type VendorCredit struct {
 	DepartmentRef *ReferenceType       `json:",omitempty"`
 	RecurDataRef  *ReferenceType       `json:",omitempty"`
 	TxnDate       *Date                `json:",omitempty"`
-	MetaData      ModificationMetaData `json:",omitempty"`
+	MetaData      ModificationMetaData `json:",omitzero"`
 	TotalAmt      json.Number          `json:",omitempty"`
 	Balance       json.Number          `json:",omitempty"`
 	ExchangeRate  json.Number          `json:",omitempty"`
@@ -26,6 +26,21 @@ type VendorCredit struct {
 	Domain        string               `json:"domain,omitempty"`
 	Status        string               `json:"status,omitempty"`
 	// ClobalTaxCalculation
+
+	// Unknown holds the fields QuickBooks sent that VendorCredit does not model.
+	Unknown UnknownFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a VendorCredit, keeping the fields it does not model in Unknown.
+func (v *VendorCredit) UnmarshalJSON(data []byte) error {
+	type vendorCredit VendorCredit
+	return unmarshalWithUnknown(data, (*vendorCredit)(v), &v.Unknown)
+}
+
+// MarshalJSON encodes a VendorCredit along with its Unknown fields.
+func (v VendorCredit) MarshalJSON() ([]byte, error) {
+	type vendorCredit VendorCredit
+	return marshalWithUnknown(vendorCredit(v), v.Unknown)
 }
 
 // CreateVendorCredit creates the given VendorCredit on the QuickBooks server, returning
@@ -54,36 +69,7 @@ func (c *Client) DeleteVendorCredit(params RequestParameters, vendorCredit *Vend
 
 // FindVendorCredits gets the full list of VendorCredits in the QuickBooks account.
 func (c *Client) FindVendorCredits(params RequestParameters) ([]VendorCredit, error) {
-	var resp struct {
-		QueryResponse struct {
-			VendorCredits []VendorCredit `json:"VendorCredit"`
-			MaxResults    int
-			StartPosition int
-			TotalCount    int
-		}
-	}
-
-	if err := c.query(params, "SELECT COUNT(*) FROM VendorCredit", &resp); err != nil {
-		return nil, err
-	}
-
-	if resp.QueryResponse.TotalCount == 0 {
-		return nil, nil
-	}
-
-	vendorCredits := make([]VendorCredit, 0, resp.QueryResponse.TotalCount)
-
-	for i := 0; i < resp.QueryResponse.TotalCount; i += QueryPageSize {
-		query := "SELECT * FROM VendorCredit ORDERBY Id STARTPOSITION " + strconv.Itoa(i+1) + " MAXRESULTS " + strconv.Itoa(QueryPageSize)
-
-		if err := c.query(params, query, &resp); err != nil {
-			return nil, err
-		}
-
-		vendorCredits = append(vendorCredits, resp.QueryResponse.VendorCredits...)
-	}
-
-	return vendorCredits, nil
+	return queryAll[VendorCredit](c, params, "")
 }
 
 func (c *Client) FindVendorCreditsByPage(params RequestParameters, startPosition, pageSize int) ([]VendorCredit, error) {
@@ -120,7 +106,7 @@ func (c *Client) FindVendorCreditById(params RequestParameters, id string) (*Ven
 }
 
 // QueryVendorCredits accepts an SQL query and returns all vendorCredits found using it
-func (c *Client) QueryVendorCredits(params RequestParameters, query string) ([]VendorCredit, error) {
+func (c *Client) QueryVendorCredits(params RequestParameters, query QueryStatement) ([]VendorCredit, error) {
 	var resp struct {
 		QueryResponse struct {
 			VendorCredits []VendorCredit `json:"VendorCredit"`
@@ -129,7 +115,7 @@ func (c *Client) QueryVendorCredits(params RequestParameters, query string) ([]V
 		}
 	}
 
-	if err := c.query(params, query, &resp); err != nil {
+	if err := c.queryStatement(params, query, &resp); err != nil {
 		return nil, err
 	}
 
@@ -142,12 +128,14 @@ func (c *Client) UpdateVendorCredit(params RequestParameters, vendorCredit *Vend
 		return nil, errors.New("missing vendorCredit id")
 	}
 
-	existingVendorCredit, err := c.FindVendorCreditById(params, vendorCredit.Id)
-	if err != nil {
-		return nil, err
-	}
+	if !params.StrictConcurrency {
+		existingVendorCredit, err := c.FindVendorCreditById(params, vendorCredit.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	vendorCredit.SyncToken = existingVendorCredit.SyncToken
+		vendorCredit.SyncToken = existingVendorCredit.SyncToken
+	}
 
 	payload := struct {
 		*VendorCredit
@@ -160,9 +148,9 @@ func (c *Client) UpdateVendorCredit(params RequestParameters, vendorCredit *Vend
 		Time         Date
 	}
 
-	if err = c.post(params, "vendorcredit", payload, &vendorCreditData, nil); err != nil {
-		return nil, err
+	if err := c.post(params, "vendorcredit", payload, &vendorCreditData, nil); err != nil {
+		return nil, conflictError(params, vendorCredit.Id, vendorCredit, err, c.FindVendorCreditById)
 	}
 
-	return &vendorCreditData.VendorCredit, err
+	return &vendorCreditData.VendorCredit, nil
 }
@@ -0,0 +1,56 @@
+package webhooks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store records processed events so redeliveries can be dropped. Stores
+// shared by several processes let only one of them process an event.
+type Store interface {
+	// MarkSeen records the event and reports whether it was new.
+	MarkSeen(ctx context.Context, eventID string) (bool, error)
+	// Forget removes the event, so it is processed again when redelivered.
+	Forget(ctx context.Context, eventID string) error
+}
+
+// MemoryStore is a Store for a single process, remembering events for a
+// fixed time.
+type MemoryStore struct {
+	ttl time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewMemoryStore returns a MemoryStore remembering events for ttl.
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{ttl: ttl, seen: make(map[string]time.Time)}
+}
+
+func (s *MemoryStore) MarkSeen(ctx context.Context, eventID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, expiry := range s.seen {
+		if now.After(expiry) {
+			delete(s.seen, id)
+		}
+	}
+
+	if _, ok := s.seen[eventID]; ok {
+		return false, nil
+	}
+	s.seen[eventID] = now.Add(s.ttl)
+
+	return true, nil
+}
+
+func (s *MemoryStore) Forget(ctx context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.seen, eventID)
+	return nil
+}
@@ -0,0 +1,365 @@
+// Package webhooks receives QuickBooks Online webhook notifications.
+//
+// A Handler verifies the intuit-signature header of every notification,
+// parses both the legacy eventNotifications payload and the CloudEvents
+// payload into Events, drops redelivered events and dispatches the rest to
+// the functions registered for their entity:
+//
+//	h := webhooks.NewHandler(webhooks.Config{VerifierToken: token})
+//	h.Handle("Invoice", func(ctx context.Context, event webhooks.Event) error {
+//		...
+//	})
+//	http.Handle("/quickbooks/webhooks", h)
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tommyhedley/quickbooks-go"
+)
+
+// SignatureHeader is the header carrying the notification's signature.
+const SignatureHeader = "intuit-signature"
+
+// MaxBodySize is the largest notification body a Handler reads.
+const MaxBodySize = 1 << 20
+
+// ErrInvalidSignature is returned by Verify when the signature does not
+// match the body.
+var ErrInvalidSignature = errors.New("webhooks: invalid intuit-signature")
+
+// Operation is the change that triggered an event.
+type Operation string
+
+const (
+	Create  Operation = "Create"
+	Update  Operation = "Update"
+	Delete  Operation = "Delete"
+	Merge   Operation = "Merge"
+	Void    Operation = "Void"
+	Emailed Operation = "Emailed"
+)
+
+// Event is a single entity change.
+type Event struct {
+	// ID identifies the event across redeliveries. It is the CloudEvents id,
+	// or derived from the event's fields for legacy payloads.
+	ID         string
+	RealmId    string
+	EntityName string
+	EntityId   string
+	Operation  Operation
+	// DeletedId is the id of the entity merged into EntityId by a Merge.
+	DeletedId   string
+	LastUpdated time.Time
+}
+
+// HandlerFunc processes an event. Returning an error makes the Handler
+// answer with a 500, so Intuit delivers the notification again.
+type HandlerFunc func(ctx context.Context, event Event) error
+
+// Config configures a Handler.
+type Config struct {
+	// VerifierToken is the app's webhook verifier token from the developer
+	// portal.
+	VerifierToken string
+	// Store records the events already processed. Defaults to a MemoryStore
+	// remembering events for a day.
+	Store Store
+}
+
+// Handler is an http.Handler receiving webhook notifications.
+type Handler struct {
+	verifierToken []byte
+	store         Store
+
+	mu       sync.RWMutex
+	handlers map[string][]HandlerFunc
+	all      []HandlerFunc
+}
+
+// NewHandler returns a Handler without any registered functions.
+func NewHandler(cfg Config) *Handler {
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStore(24 * time.Hour)
+	}
+
+	return &Handler{
+		verifierToken: []byte(cfg.VerifierToken),
+		store:         cfg.Store,
+		handlers:      make(map[string][]HandlerFunc),
+	}
+}
+
+// Handle registers fn for the events of the named entity, such as
+// "Invoice".
+func (h *Handler) Handle(entityName string, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[entityName] = append(h.handlers[entityName], fn)
+}
+
+// HandleAll registers fn for every event.
+func (h *Handler) HandleAll(fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.all = append(h.all, fn)
+}
+
+// ServeHTTP verifies, parses and dispatches a notification.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := Verify(body, r.Header.Get(SignatureHeader), string(h.verifierToken)); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	events, err := Parse(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(r.Context(), events); err != nil {
+		http.Error(w, "failed to process events", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Dispatch runs the registered functions for every event not seen before.
+// Events whose functions fail are forgotten again, so a redelivery is
+// processed. The errors of all failed events are joined.
+func (h *Handler) Dispatch(ctx context.Context, events []Event) error {
+	var errs []error
+	for _, event := range events {
+		first, err := h.store.MarkSeen(ctx, event.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to record event %s: %w", event.ID, err))
+			continue
+		}
+		if !first {
+			continue
+		}
+
+		if err := h.dispatch(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("event %s: %w", event.ID, err))
+			if err := h.store.Forget(ctx, event.ID); err != nil {
+				errs = append(errs, fmt.Errorf("failed to forget event %s: %w", event.ID, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h *Handler) dispatch(ctx context.Context, event Event) error {
+	h.mu.RLock()
+	fns := append(append([]HandlerFunc(nil), h.handlers[event.EntityName]...), h.all...)
+	h.mu.RUnlock()
+
+	for _, fn := range fns {
+		if err := fn(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Verify checks that signature, the value of the intuit-signature header,
+// is the base64 HMAC-SHA256 of body keyed with verifierToken.
+func Verify(body []byte, signature, verifierToken string) error {
+	if signature == "" || verifierToken == "" {
+		return ErrInvalidSignature
+	}
+
+	got, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(verifierToken))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+type legacyPayload struct {
+	EventNotifications []struct {
+		RealmId         string `json:"realmId"`
+		DataChangeEvent struct {
+			Entities []struct {
+				Name        string `json:"name"`
+				Id          string `json:"id"`
+				Operation   string `json:"operation"`
+				LastUpdated string `json:"lastUpdated"`
+				DeletedId   string `json:"deletedId"`
+			} `json:"entities"`
+		} `json:"dataChangeEvent"`
+	} `json:"eventNotifications"`
+}
+
+type cloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	Id              string `json:"id"`
+	Type            string `json:"type"`
+	Time            string `json:"time"`
+	IntuitEntityId  string `json:"intuitentityid"`
+	IntuitAccountId string `json:"intuitaccountid"`
+	Data            struct {
+		DeletedId string `json:"deletedId"`
+	} `json:"data"`
+}
+
+// Parse decodes a notification body, in either the legacy
+// eventNotifications format or as a batch of CloudEvents.
+func Parse(body []byte) ([]Event, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return parseCloudEvents(trimmed)
+	}
+
+	var payload legacyPayload
+	if err := json.Unmarshal(trimmed, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode notification: %v", err)
+	}
+	if payload.EventNotifications == nil {
+		var event cloudEvent
+		if err := json.Unmarshal(trimmed, &event); err == nil && event.SpecVersion != "" {
+			return parseCloudEvents([]byte("[" + string(trimmed) + "]"))
+		}
+		return nil, errors.New("notification holds no eventNotifications")
+	}
+
+	var events []Event
+	for _, notification := range payload.EventNotifications {
+		for _, entity := range notification.DataChangeEvent.Entities {
+			lastUpdated, err := parseTime(entity.LastUpdated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse lastUpdated of %s %s: %v", entity.Name, entity.Id, err)
+			}
+
+			events = append(events, Event{
+				ID:          strings.Join([]string{notification.RealmId, entity.Name, entity.Id, entity.Operation, entity.LastUpdated}, "/"),
+				RealmId:     notification.RealmId,
+				EntityName:  entity.Name,
+				EntityId:    entity.Id,
+				Operation:   Operation(entity.Operation),
+				DeletedId:   entity.DeletedId,
+				LastUpdated: lastUpdated,
+			})
+		}
+	}
+
+	return events, nil
+}
+
+func parseCloudEvents(body []byte) ([]Event, error) {
+	var payload []cloudEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode notification: %v", err)
+	}
+
+	events := make([]Event, 0, len(payload))
+	for _, ce := range payload {
+		// Types look like qbo.invoice.created.v1.
+		parts := strings.Split(ce.Type, ".")
+		if len(parts) < 3 || parts[0] != "qbo" {
+			return nil, fmt.Errorf("unsupported event type %q", ce.Type)
+		}
+
+		operation, ok := cloudEventOperations[parts[2]]
+		if !ok {
+			operation = Operation(titleCase(parts[2]))
+		}
+
+		lastUpdated, err := parseTime(ce.Time)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse time of event %s: %v", ce.Id, err)
+		}
+
+		events = append(events, Event{
+			ID:          ce.Id,
+			RealmId:     ce.IntuitAccountId,
+			EntityName:  entityName(parts[1]),
+			EntityId:    ce.IntuitEntityId,
+			Operation:   operation,
+			DeletedId:   ce.Data.DeletedId,
+			LastUpdated: lastUpdated,
+		})
+	}
+
+	return events, nil
+}
+
+var cloudEventOperations = map[string]Operation{
+	"created": Create,
+	"updated": Update,
+	"deleted": Delete,
+	"merged":  Merge,
+	"voided":  Void,
+	"emailed": Emailed,
+}
+
+// entityName maps the lower case entity of a CloudEvents type to the name
+// used by the API.
+func entityName(name string) string {
+	for _, known := range quickbooks.EntityNames() {
+		if strings.EqualFold(known, name) {
+			return known
+		}
+	}
+	return titleCase(name)
+}
+
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.000-0700", "2006-01-02T15:04:05-0700"}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, err
+}
@@ -0,0 +1,105 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const verifierToken = "verifier"
+
+const legacyBody = `{"eventNotifications":[{"realmId":"1185883450","dataChangeEvent":{"entities":[
+	{"name":"Customer","id":"1","operation":"Create","lastUpdated":"2015-10-05T14:42:19-0700"},
+	{"name":"Vendor","id":"4","operation":"Merge","lastUpdated":"2015-10-05T14:42:19.000-0700","deletedId":"7"}
+]}}]}`
+
+const cloudEventsBody = `[{"specversion":"1.0","id":"88cd52a5-1c5f-4b5e-a3f6-2f3a5ce4a1d0","source":"intuit.abc","type":"qbo.billpayment.voided.v1",
+	"datacontenttype":"application/json","time":"2025-09-10T21:31:25.179Z","intuitentityid":"130","intuitaccountid":"310687","data":{}}]`
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(verifierToken))
+	mac.Write([]byte(body))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func post(h http.Handler, body, signature string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestParse(t *testing.T) {
+	events, err := Parse([]byte(legacyBody))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "1185883450", events[0].RealmId)
+	assert.Equal(t, "Customer", events[0].EntityName)
+	assert.Equal(t, Create, events[0].Operation)
+	assert.Equal(t, time.Date(2015, 10, 5, 21, 42, 19, 0, time.UTC), events[0].LastUpdated.UTC())
+	assert.Equal(t, "7", events[1].DeletedId)
+
+	events, err = Parse([]byte(cloudEventsBody))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, Event{
+		ID:          "88cd52a5-1c5f-4b5e-a3f6-2f3a5ce4a1d0",
+		RealmId:     "310687",
+		EntityName:  "BillPayment",
+		EntityId:    "130",
+		Operation:   Void,
+		LastUpdated: time.Date(2025, 9, 10, 21, 31, 25, 179000000, time.UTC),
+	}, events[0])
+}
+
+func TestHandlerVerifiesAndDeduplicates(t *testing.T) {
+	h := NewHandler(Config{VerifierToken: verifierToken})
+
+	var customers, all []Event
+	h.Handle("Customer", func(ctx context.Context, event Event) error {
+		customers = append(customers, event)
+		return nil
+	})
+	h.HandleAll(func(ctx context.Context, event Event) error {
+		all = append(all, event)
+		return nil
+	})
+
+	assert.Equal(t, http.StatusUnauthorized, post(h, legacyBody, sign(legacyBody+" ")))
+	assert.Equal(t, http.StatusUnauthorized, post(h, legacyBody, ""))
+	assert.Empty(t, all)
+
+	assert.Equal(t, http.StatusOK, post(h, legacyBody, sign(legacyBody)))
+	assert.Equal(t, http.StatusOK, post(h, legacyBody, sign(legacyBody)))
+	assert.Len(t, customers, 1)
+	assert.Len(t, all, 2)
+}
+
+func TestHandlerRedeliversFailedEvents(t *testing.T) {
+	h := NewHandler(Config{VerifierToken: verifierToken})
+
+	calls := 0
+	h.Handle("BillPayment", func(ctx context.Context, event Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, post(h, cloudEventsBody, sign(cloudEventsBody)))
+	assert.Equal(t, http.StatusOK, post(h, cloudEventsBody, sign(cloudEventsBody)))
+	assert.Equal(t, http.StatusOK, post(h, cloudEventsBody, sign(cloudEventsBody)))
+	assert.Equal(t, 2, calls)
+}